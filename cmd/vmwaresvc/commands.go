@@ -0,0 +1,481 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// commands are the subcommands of vmwaresvc.
+var commands = []command{
+	{
+		path:    "sites list",
+		summary: "List director site instances",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.ListWorkloadDomainInstancesWithContext(ctx, cli.service.NewListWorkloadDomainInstancesOptions())
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "sites get",
+		args:    "SITE_ID",
+		summary: "Get a director site instance",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			wait := flags.Bool("wait", false, "wait for the director site to be ready to use")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID"); err != nil {
+					return nil, err
+				}
+				if *wait {
+					return cli.service.WaitForDirectorSite(ctx, args[0], cli.wait)
+				}
+				result, _, err := cli.service.GetSpecificWorkloadDomainInstanceWithContext(ctx, cli.service.NewGetSpecificWorkloadDomainInstanceOptions(args[0]))
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "sites create",
+		summary: "Create a director site instance",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			name := flags.String("name", "", "name of the director site instance (required)")
+			resourceGroup := flags.String("resource-group", "", "name or ID of the resource group (required)")
+			clusters := &clusterFlag{}
+			flags.Var(clusters, "cluster", "cluster to order, as name=NAME,location=DATACENTER,hosts=COUNT,profile=HOST_PROFILE,TIER=GB (repeatable, required)")
+			validate := flags.Bool("validate", true, "validate the order against the regions and host profiles before creating it")
+			wait := flags.Bool("wait", false, "wait for the director site to be ready to use")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				if err := requireFlag("name", *name); err != nil {
+					return nil, err
+				}
+				if err := requireFlag("resource-group", *resourceGroup); err != nil {
+					return nil, err
+				}
+				if len(clusters.orders) == 0 {
+					return nil, fmt.Errorf("%w: at least one --cluster is required", errUsage)
+				}
+				createOptions := cli.service.NewCreateWorkloadDomainOptions(*name, *resourceGroup, clusters.orders)
+				if *validate {
+					if err := cli.service.ValidateWorkloadDomainOrder(ctx, createOptions); err != nil {
+						return nil, err
+					}
+				}
+				result, _, err := cli.service.CreateWorkloadDomainWithContext(ctx, createOptions)
+				if err != nil || !*wait {
+					return result, err
+				}
+				return cli.service.WaitForDirectorSite(ctx, *result.ID, cli.wait)
+			}
+		},
+	},
+	{
+		path:    "sites delete",
+		args:    "SITE_ID",
+		summary: "Delete a director site instance",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			wait := flags.Bool("wait", false, "wait for the director site to be deleted")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID"); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.DeleteWorkloadDomainWithContext(ctx, cli.service.NewDeleteWorkloadDomainOptions(args[0]))
+				if err != nil || !*wait {
+					return result, err
+				}
+				return cli.service.WaitForDirectorSite(ctx, args[0], cli.waitFor([]string{vmwarev1.DirectorSite_Status_Deleted}, nil))
+			}
+		},
+	},
+	{
+		path:    "clusters list",
+		args:    "SITE_ID",
+		summary: "List the clusters of a director site instance",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID"); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.ListClusterInstancesWithContext(ctx, cli.service.NewListClusterInstancesOptions(args[0]))
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "clusters get",
+		args:    "SITE_ID CLUSTER_ID",
+		summary: "Get a cluster of a director site instance",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			wait := flags.Bool("wait", false, "wait for the cluster to be ready to use")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID", "CLUSTER_ID"); err != nil {
+					return nil, err
+				}
+				if *wait {
+					return cli.service.WaitForCluster(ctx, args[0], args[1], cli.wait)
+				}
+				result, _, err := cli.service.GetSpecificClusterInstanceWithContext(ctx, cli.service.NewGetSpecificClusterInstanceOptions(args[0], args[1]))
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "clusters resize",
+		args:    "SITE_ID CLUSTER_ID",
+		summary: "Set the number of hosts of a cluster",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			hosts := flags.Int64("hosts", 0, "number of hosts of the cluster (required)")
+			wait := flags.Bool("wait", false, "wait for the cluster to reach the new host count")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID", "CLUSTER_ID"); err != nil {
+					return nil, err
+				}
+				if *hosts < 1 {
+					return nil, fmt.Errorf("%w: --hosts must be at least 1", errUsage)
+				}
+				if *wait {
+					return cli.service.ResizeCluster(ctx, args[0], args[1], *hosts, cli.wait)
+				}
+				result, _, err := cli.service.SetHostsCountWithContext(ctx, cli.service.NewSetHostsCountOptions(args[0], args[1], *hosts))
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "clusters set-file-shares",
+		args:    "SITE_ID CLUSTER_ID",
+		summary: "Set the storage of a cluster",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			storage := &storageFlag{}
+			flags.Var(storage, "storage", "storage tier and size, as TIER=GB, such as STORAGE_TWO_IOPS_GB=24000 (repeatable, required)")
+			wait := flags.Bool("wait", false, "wait for the cluster to be ready to use")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID", "CLUSTER_ID"); err != nil {
+					return nil, err
+				}
+				if !storage.set {
+					return nil, fmt.Errorf("%w: at least one --storage is required", errUsage)
+				}
+				setOptions := cli.service.NewSetFileSharesOptions(args[0], args[1])
+				setOptions.STORAGEPOINTTWOFIVEIOPSGB = storage.fileShares.STORAGEPOINTTWOFIVEIOPSGB
+				setOptions.STORAGETWOIOPSGB = storage.fileShares.STORAGETWOIOPSGB
+				setOptions.STORAGEFOURIOPSGB = storage.fileShares.STORAGEFOURIOPSGB
+				setOptions.STORAGETENIOPSGB = storage.fileShares.STORAGETENIOPSGB
+				result, _, err := cli.service.SetFileSharesWithContext(ctx, setOptions)
+				if err != nil || !*wait {
+					return result, err
+				}
+				return cli.service.WaitForCluster(ctx, args[0], args[1], cli.wait)
+			}
+		},
+	},
+	{
+		path:    "vdcs list",
+		summary: "List Virtual Data Centers",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.ListVdcsWithContext(ctx, cli.service.NewListVdcsOptions())
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "vdcs get",
+		args:    "VDC_ID",
+		summary: "Get a Virtual Data Center",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			wait := flags.Bool("wait", false, "wait for the Virtual Data Center to be ready to use")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "VDC_ID"); err != nil {
+					return nil, err
+				}
+				if *wait {
+					return cli.service.WaitForVdc(ctx, args[0], cli.wait)
+				}
+				result, _, err := cli.service.GetVdcWithContext(ctx, cli.service.NewGetVdcOptions(args[0]))
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "vdcs create",
+		summary: "Create a Virtual Data Center",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			name := flags.String("name", "", "name of the Virtual Data Center (required)")
+			siteID := flags.String("site", "", "ID of the director site to deploy the Virtual Data Center in (required)")
+			clusterID := flags.String("cluster", "", "ID of the cluster to deploy the Virtual Data Center on (required)")
+			edgeType := flags.String("edge-type", "", "type of the networking edge: shared or dedicated")
+			edgeSize := flags.String("edge-size", "", "size of a dedicated edge: medium, large or extra_large")
+			resourceGroupID := flags.String("resource-group-id", "", "ID of the resource group, defaults to the default resource group of the account")
+			wait := flags.Bool("wait", false, "wait for the Virtual Data Center to be ready to use")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				for _, required := range []struct{ flag, value string }{{"name", *name}, {"site", *siteID}, {"cluster", *clusterID}} {
+					if err := requireFlag(required.flag, required.value); err != nil {
+						return nil, err
+					}
+				}
+				directorSite, err := cli.service.NewNewVDCDirectorSite(*siteID, &vmwarev1.VDCDirectorSiteCluster{ID: core.StringPtr(*clusterID)})
+				if err != nil {
+					return nil, err
+				}
+				createOptions := cli.service.NewCreateVdcOptions(*name, directorSite)
+				if *edgeType != "" {
+					edge, err := cli.service.NewNewVDCEdge(*edgeType)
+					if err != nil {
+						return nil, err
+					}
+					if *edgeSize != "" {
+						edge.Size = core.StringPtr(*edgeSize)
+					}
+					createOptions.SetEdge(edge)
+				}
+				if *resourceGroupID != "" {
+					resourceGroup, err := cli.service.NewNewVDCResourceGroup(*resourceGroupID)
+					if err != nil {
+						return nil, err
+					}
+					createOptions.SetResourceGroup(resourceGroup)
+				}
+				result, _, err := cli.service.CreateVdcWithContext(ctx, createOptions)
+				if err != nil || !*wait {
+					return result, err
+				}
+				return cli.service.WaitForVdc(ctx, *result.ID, cli.wait)
+			}
+		},
+	},
+	{
+		path:    "vdcs delete",
+		args:    "VDC_ID",
+		summary: "Delete a Virtual Data Center",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			wait := flags.Bool("wait", false, "wait for the Virtual Data Center to be deleted")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "VDC_ID"); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.DeleteVdcWithContext(ctx, cli.service.NewDeleteVdcOptions(args[0]))
+				if err != nil || !*wait {
+					return result, err
+				}
+				deleted, err := cli.service.WaitForVdc(ctx, args[0], cli.waitFor([]string{vmwarev1.VDC_Status_Deleted}, []string{vmwarev1.VDC_Status_Failed}))
+				if deleted == nil {
+					// The Virtual Data Center is gone; report the last status returned by the service.
+					deleted = result
+				}
+				return deleted, err
+			}
+		},
+	},
+	{
+		path:    "regions",
+		summary: "List the regions and data centers where director sites can be deployed",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.GetRegionsWithContext(ctx, cli.service.NewGetRegionsOptions())
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "host-profiles",
+		summary: "List the host profiles available for clusters",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.ViewInstanceWithContext(ctx, cli.service.NewViewInstanceOptions())
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "prices",
+		summary: "List the price catalog of director sites",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.ListPricesWithContext(ctx, cli.service.NewListPricesOptions())
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "quote",
+		summary: "Get a price quote for a director site",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			country := flags.String("country", "", "billing country")
+			clusters := &clusterFlag{}
+			flags.Var(clusters, "cluster", "cluster to quote, as name=NAME,hosts=COUNT,profile=HOST_PROFILE,TIER=GB (repeatable, required)")
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args); err != nil {
+					return nil, err
+				}
+				if len(clusters.orders) == 0 {
+					return nil, fmt.Errorf("%w: at least one --cluster is required", errUsage)
+				}
+				quoteOptions := cli.service.NewGetVcddPriceOptions()
+				if *country != "" {
+					quoteOptions.SetCountry(*country)
+				}
+				for _, order := range clusters.orders {
+					quoteOptions.Clusters = append(quoteOptions.Clusters, vmwarev1.DirectorSitePriceQuoteClusterInfo{
+						Name:        order.Name,
+						HostProfile: order.HostProfile,
+						HostCount:   order.HostCount,
+						FileShares:  order.FileShares,
+					})
+				}
+				result, _, err := cli.service.GetVcddPriceWithContext(ctx, quoteOptions)
+				return result, err
+			}
+		},
+	},
+	{
+		path:    "password rotate",
+		args:    "SITE_ID",
+		summary: "Replace the password of the VMware Cloud Director organization administrator",
+		setup: func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+			return func(ctx context.Context, cli *cli, args []string) (interface{}, error) {
+				if err := requireArgs(args, "SITE_ID"); err != nil {
+					return nil, err
+				}
+				result, _, err := cli.service.ReplaceOrgAdminPasswordWithContext(ctx, cli.service.NewReplaceOrgAdminPasswordOptions(args[0]))
+				return result, err
+			}
+		},
+	},
+}
+
+// waitFor returns the wait options of the invocation with the target and failed statuses replaced.
+func (cli *cli) waitFor(targetStatuses []string, failedStatuses []string) *vmwarev1.WaitOptions {
+	opts := *cli.wait
+	opts.TargetStatuses = targetStatuses
+	opts.FailedStatuses = failedStatuses
+	return &opts
+}
+
+// storageFlag : A repeatable --storage TIER=GB flag
+type storageFlag struct {
+	fileShares vmwarev1.FileShares
+	set        bool
+}
+
+func (f *storageFlag) String() string {
+	return ""
+}
+
+func (f *storageFlag) Set(value string) error {
+	tier, size, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected TIER=GB, got %q", value)
+	}
+	if err := setFileShare(&f.fileShares, tier, size); err != nil {
+		return err
+	}
+	f.set = true
+	return nil
+}
+
+// clusterFlag : A repeatable --cluster flag holding comma-separated key=value pairs
+type clusterFlag struct {
+	orders []vmwarev1.ClusterOrderInfo
+}
+
+func (f *clusterFlag) String() string {
+	return ""
+}
+
+func (f *clusterFlag) Set(value string) error {
+	order := vmwarev1.ClusterOrderInfo{FileShares: &vmwarev1.FileShares{}}
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return fmt.Errorf("expected key=value, got %q", pair)
+		}
+		switch key {
+		case "name":
+			order.Name = core.StringPtr(val)
+		case "location":
+			order.Location = core.StringPtr(val)
+		case "profile", "host_profile":
+			order.HostProfile = core.StringPtr(val)
+		case "hosts", "host_count":
+			count, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid host count %q", val)
+			}
+			order.HostCount = core.Int64Ptr(count)
+		default:
+			if err := setFileShare(order.FileShares, key, val); err != nil {
+				return err
+			}
+		}
+	}
+	if order.Name == nil {
+		return fmt.Errorf("the cluster name is required")
+	}
+	f.orders = append(f.orders, order)
+	return nil
+}
+
+// setFileShare sets the size of the storage tier named like the FileShares JSON key, ignoring case.
+func setFileShare(fileShares *vmwarev1.FileShares, tier string, size string) error {
+	gb, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid storage size %q", size)
+	}
+	switch strings.ToUpper(tier) {
+	case vmwarev1.StorageTierPointTwoFiveIopsGB:
+		fileShares.STORAGEPOINTTWOFIVEIOPSGB = core.Int64Ptr(gb)
+	case vmwarev1.StorageTierTwoIopsGB:
+		fileShares.STORAGETWOIOPSGB = core.Int64Ptr(gb)
+	case vmwarev1.StorageTierFourIopsGB:
+		fileShares.STORAGEFOURIOPSGB = core.Int64Ptr(gb)
+	case vmwarev1.StorageTierTenIopsGB:
+		fileShares.STORAGETENIOPSGB = core.Int64Ptr(gb)
+	default:
+		return fmt.Errorf("unknown storage tier %q", tier)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command vmwaresvc : A command-line client for the IBM Cloud for VMware as a Service API
+//
+// Every operation of vmwarev1.VmwareV1 is available as a subcommand:
+//
+//	vmwaresvc sites list|get|create|delete
+//	vmwaresvc clusters list|get|resize|set-file-shares
+//	vmwaresvc vdcs list|get|create|delete
+//	vmwaresvc regions
+//	vmwaresvc host-profiles
+//	vmwaresvc prices
+//	vmwaresvc quote
+//	vmwaresvc password rotate
+//
+// The client is configured from the same environment variables as vmwarev1.NewVmwareV1UsingExternalConfig, such as
+// VMWARE_APIKEY, VMWARE_AUTH_TYPE and VMWARE_URL. Results are printed as a table by default, or as JSON or YAML with
+// --output. Asynchronous operations accept --wait to block until the resource settles.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// command : A subcommand of vmwaresvc
+type command struct {
+	// The words that select the command, such as "sites list".
+	path string
+
+	// The positional arguments of the command, for the usage message.
+	args string
+
+	// A one-line description of the command.
+	summary string
+
+	// setup defines the flags of the command and returns the function that runs it. The function receives the
+	// positional arguments and returns the result to print, or nil when there is nothing to print.
+	setup func(flags *flag.FlagSet) func(ctx context.Context, cli *cli, args []string) (interface{}, error)
+}
+
+// cli : The state shared by the commands of a single invocation
+type cli struct {
+	service *vmwarev1.VmwareV1
+	stderr  io.Writer
+	wait    *vmwarev1.WaitOptions
+}
+
+// errUsage is returned by commands that were invoked with invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+// run executes the command line args and returns the exit code.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		printUsage(stderr)
+		if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			return 0
+		}
+		return 2
+	}
+
+	flags := flag.NewFlagSet("vmwaresvc "+cmd.path, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("output", formatTable, "output format: table, json or yaml")
+	flags.StringVar(output, "o", formatTable, "shorthand for --output")
+	serviceName := flags.String("service-name", vmwarev1.DefaultServiceName, "prefix of the environment variables that configure the client")
+	pollInterval := flags.Duration("poll-interval", 10*time.Second, "delay between two polls when waiting")
+	timeout := flags.Duration("timeout", vmwarev1.DefaultWaitTimeout, "maximum time to wait")
+	runCommand := cmd.setup(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: vmwaresvc %s [flags] %s\n\n%s\n\nFlags:\n", cmd.path, cmd.args, cmd.summary)
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, rest)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	formatter, err := newFormatter(*output)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err.Error())
+		return 2
+	}
+
+	service, err := vmwarev1.NewVmwareV1UsingExternalConfig(&vmwarev1.VmwareV1Options{ServiceName: *serviceName})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: unable to configure the client: %s\n", err.Error())
+		return 1
+	}
+	c := &cli{
+		service: service,
+		stderr:  stderr,
+		wait: &vmwarev1.WaitOptions{
+			PollInterval: *pollInterval,
+			Timeout:      *timeout,
+			OnStatusChange: func(transition vmwarev1.StatusTransition) {
+				fmt.Fprintf(stderr, "%s %s: %s\n", transition.Resource, transition.ID, transition.To)
+			},
+		},
+	}
+
+	result, err := runCommand(ctx, c, positional)
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "Error: %s\n\n", err.Error())
+			flags.Usage()
+			return 2
+		}
+		fmt.Fprintf(stderr, "Error: %s\n", err.Error())
+		return 1
+	}
+	if result == nil {
+		return 0
+	}
+	if err = formatter(stdout, result); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
+
+// findCommand returns the command selected by the leading words of args and the remaining args.
+func findCommand(args []string) (*command, []string) {
+	var best *command
+	bestWords := 0
+	for i := range commands {
+		words := strings.Fields(commands[i].path)
+		if len(words) > len(args) || len(words) <= bestWords {
+			continue
+		}
+		matched := true
+		for j, word := range words {
+			if args[j] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best = &commands[i]
+			bestWords = len(words)
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	return best, args[bestWords:]
+}
+
+// parseInterspersed parses flags that appear before, between or after the positional arguments and returns the
+// positional arguments.
+func parseInterspersed(flags *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err = flags.Parse(args); err != nil {
+			return
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return
+		}
+		if args[0] == "--" {
+			positional = append(positional, args[1:]...)
+			return
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: vmwaresvc <command> [flags] [args]\n\nCommands:\n")
+	paths := make([]string, 0, len(commands))
+	summaries := make(map[string]string)
+	for _, cmd := range commands {
+		paths = append(paths, cmd.path)
+		summaries[cmd.path] = cmd.summary
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(w, "  %-26s %s\n", path, summaries[path])
+	}
+	fmt.Fprintf(w, "\nRun 'vmwaresvc <command> --help' for the flags of a command.\n")
+	fmt.Fprintf(w, "The client is configured from the VMWARE_* environment variables, such as VMWARE_APIKEY and VMWARE_URL.\n")
+}
+
+// requireArgs returns errUsage unless exactly the named positional arguments were given.
+func requireArgs(args []string, names ...string) error {
+	if len(args) != len(names) {
+		return fmt.Errorf("%w: expected %d argument(s) (%s), got %d", errUsage, len(names), strings.Join(names, ", "), len(args))
+	}
+	return nil
+}
+
+// requireFlag returns errUsage when a mandatory flag is empty.
+func requireFlag(name string, value string) error {
+	if value == "" {
+		return fmt.Errorf("%w: --%s is required", errUsage, name)
+	}
+	return nil
+}
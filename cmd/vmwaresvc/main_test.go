@@ -0,0 +1,175 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// startFake starts a fake server and points the environment configuration of the client at it.
+func startFake(t *testing.T) *vmwarev1fake.Server {
+	server := vmwarev1fake.NewServer(nil)
+	t.Cleanup(server.Close)
+	t.Setenv("VMWARE_AUTH_TYPE", "noauth")
+	t.Setenv("VMWARE_URL", server.URL)
+	return server
+}
+
+// vmwaresvc runs the command line and returns its exit code, standard output and standard error.
+func vmwaresvc(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append(args, "--poll-interval", "1ms"), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSitesLifecycle(t *testing.T) {
+	startFake(t)
+
+	code, stdout, stderr := vmwaresvc("sites", "create", "--name", "site1", "--resource-group", "Default",
+		"--cluster", "name=cluster1,location=dal10,hosts=2,profile="+vmwarev1fake.HostProfile192GB+",STORAGE_TWO_IOPS_GB=24000",
+		"--wait", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	var site vmwarev1.DirectorSite
+	require.Nil(t, json.Unmarshal([]byte(stdout), &site))
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Readytouse, *site.Status)
+	assert.Contains(t, stderr, "director site "+*site.ID+": ReadyToUse")
+	clusterID := *site.Clusters[0].ID
+
+	code, stdout, stderr = vmwaresvc("sites", "list")
+	require.Equal(t, 0, code, stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^ID\s+NAME\s+STATUS\s+RESOURCE GROUP\s+CLUSTERS\s+CREATED$`, lines[0])
+	assert.Regexp(t, `^`+*site.ID+`\s+site1\s+ReadyToUse\s+Default\s+1\s+`, lines[1])
+
+	code, stdout, stderr = vmwaresvc("clusters", "resize", *site.ID, clusterID, "--hosts", "3", "--wait", "-o", "yaml")
+	require.Equal(t, 0, code, stderr)
+	var cluster map[string]interface{}
+	require.Nil(t, yaml.Unmarshal([]byte(stdout), &cluster))
+	assert.Equal(t, 3, cluster["host_count"])
+
+	code, stdout, stderr = vmwaresvc("clusters", "set-file-shares", *site.ID, clusterID, "--storage", "storage_four_iops_gb=500", "--wait")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "STORAGE_FOUR_IOPS_GB=500")
+
+	code, stdout, stderr = vmwaresvc("clusters", "list", *site.ID)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "cluster1")
+
+	code, stdout, stderr = vmwaresvc("password", "rotate", *site.ID)
+	require.Equal(t, 0, code, stderr)
+	assert.True(t, strings.HasPrefix(stdout, "PASSWORD"))
+
+	code, _, stderr = vmwaresvc("sites", "delete", *site.ID, "--wait")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stderr, "director site "+*site.ID+": Deleted")
+}
+
+func TestVdcsLifecycle(t *testing.T) {
+	server := startFake(t)
+	service, err := server.NewClient()
+	require.Nil(t, err)
+	cluster, err := service.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{}, vmwarev1fake.HostProfile192GB)
+	require.Nil(t, err)
+	site, _, err := service.CreateWorkloadDomain(service.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+	require.Nil(t, err)
+	server.CompletePending()
+
+	code, stdout, stderr := vmwaresvc("vdcs", "create", "--name", "vdc1", "--site", *site.ID, "--cluster", *site.Clusters[0].ID, "--edge-type", "shared", "--wait", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	var vdc vmwarev1.VDC
+	require.Nil(t, json.Unmarshal([]byte(stdout), &vdc))
+	assert.Equal(t, vmwarev1.VDC_Status_Readytouse, *vdc.Status)
+
+	code, stdout, stderr = vmwaresvc("vdcs", "get", *vdc.ID)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "vdc1")
+
+	code, stdout, stderr = vmwaresvc("vdcs", "list", "-o", "yaml")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "name: vdc1")
+
+	code, _, stderr = vmwaresvc("vdcs", "delete", *vdc.ID, "--wait")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stderr, "VDC "+*vdc.ID+": Deleted")
+}
+
+func TestReferenceData(t *testing.T) {
+	startFake(t)
+
+	code, stdout, stderr := vmwaresvc("regions")
+	require.Equal(t, 0, code, stderr)
+	assert.Regexp(t, `(?m)^eu-de\s+fra02\s+Frankfurt 2\s+10 Gbps$`, stdout)
+
+	code, stdout, stderr = vmwaresvc("host-profiles")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, vmwarev1fake.HostProfile768GB)
+
+	code, stdout, stderr = vmwaresvc("prices", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"director_site_pricing"`)
+
+	code, stdout, stderr = vmwaresvc("quote", "--country", "USA", "--cluster", "name=cluster1,hosts=2,profile="+vmwarev1fake.HostProfile192GB)
+	require.Equal(t, 0, code, stderr)
+	assert.Regexp(t, `(?m)^TOTAL\s+7500.00\s+USD$`, stdout)
+}
+
+func TestErrors(t *testing.T) {
+	server := startFake(t)
+
+	code, _, stderr := vmwaresvc("sites", "get")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "expected 1 argument(s) (SITE_ID)")
+
+	code, _, stderr = vmwaresvc("sites", "create", "--name", "site1", "--resource-group", "Default",
+		"--cluster", "name=cluster1,location=nowhere,hosts=1,profile="+vmwarev1fake.HostProfile192GB+",STORAGE_TWO_IOPS_GB=1")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "clusters[0].location")
+	assert.Contains(t, stderr, "clusters[0].host_count")
+	assert.Equal(t, 0, server.RequestCount("CreateWorkloadDomain"))
+
+	// The missing flags are checked in a fixed order, so the same one is always reported.
+	for i := 0; i < 5; i++ {
+		code, _, stderr = vmwaresvc("vdcs", "create", "--cluster", "cluster1")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "--name is required")
+	}
+
+	code, _, stderr = vmwaresvc("vdcs", "get", "missing")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "GetVdc")
+
+	code, _, _ = vmwaresvc("regions", "-o", "xml")
+	assert.Equal(t, 2, code)
+
+	code, _, stderr = vmwaresvc("unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "sites create")
+
+	code, _, _ = vmwaresvc("help")
+	assert.Equal(t, 0, code)
+}
@@ -0,0 +1,236 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// formatter writes a command result to w.
+type formatter func(w io.Writer, result interface{}) error
+
+func newFormatter(format string) (formatter, error) {
+	switch format {
+	case formatTable:
+		return writeTable, nil
+	case formatJSON:
+		return writeJSON, nil
+	case formatYAML:
+		return writeYAML, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q; use table, json or yaml", format)
+}
+
+func writeJSON(w io.Writer, result interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// writeYAML writes the result with the field names of its JSON form.
+func writeYAML(w io.Writer, result interface{}) error {
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err = json.Unmarshal(buf, &generic); err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(generic); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// writeTable writes the result as an aligned table. Results without a table layout are written as JSON.
+func writeTable(w io.Writer, result interface{}) error {
+	header, rows := tableOf(result)
+	if header == nil {
+		return writeJSON(w, result)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+var (
+	siteHeader    = []string{"ID", "NAME", "STATUS", "RESOURCE GROUP", "CLUSTERS", "CREATED"}
+	clusterHeader = []string{"ID", "NAME", "LOCATION", "HOSTS", "HOST PROFILE", "STATUS", "STORAGE (GB)"}
+	vdcHeader     = []string{"ID", "NAME", "STATUS", "SITE", "CLUSTER", "EDGE", "PUBLIC IPS"}
+)
+
+// tableOf returns the header and rows of the table layout of the result, or a nil header when it has none.
+func tableOf(result interface{}) (header []string, rows [][]string) {
+	switch result := result.(type) {
+	case *vmwarev1.ListDirectorSites:
+		header = siteHeader
+		for i := range result.DirectorSites {
+			rows = append(rows, siteRow(&result.DirectorSites[i]))
+		}
+	case *vmwarev1.DirectorSite:
+		header, rows = siteHeader, [][]string{siteRow(result)}
+	case *vmwarev1.ListClusters:
+		header = clusterHeader
+		for i := range result.Clusters {
+			rows = append(rows, clusterRow(&result.Clusters[i]))
+		}
+	case *vmwarev1.Cluster:
+		header, rows = clusterHeader, [][]string{clusterRow(result)}
+	case *vmwarev1.ListVDCs:
+		header = vdcHeader
+		for i := range result.Vdcs {
+			rows = append(rows, vdcRow(&result.Vdcs[i]))
+		}
+	case *vmwarev1.VDC:
+		header, rows = vdcHeader, [][]string{vdcRow(result)}
+	case *vmwarev1.DirectorSiteRegions:
+		header = []string{"REGION", "DATA CENTER", "DISPLAY NAME", "UPLINK SPEED"}
+		regions := make([]string, 0, len(result.DirectorSiteRegions))
+		for region := range result.DirectorSiteRegions {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+		for _, region := range regions {
+			for _, datacenter := range result.DirectorSiteRegions[region].Datacenters {
+				rows = append(rows, []string{region, str(datacenter.Name), str(datacenter.DisplayName), str(datacenter.UplinkSpeed)})
+			}
+		}
+	case *vmwarev1.ListHostProfiles:
+		header = []string{"PROFILE", "CPU TYPE", "CPUS", "RAM (GB)", "LOCAL DISKS"}
+		for _, profile := range result.DirectorSiteHostProfiles {
+			disks := make([]string, len(profile.LocalDisks))
+			for i, disk := range profile.LocalDisks {
+				disks[i] = fmt.Sprintf("%sx%sGB %s", integer(disk.Quantity), integer(disk.Size), str(disk.Type))
+			}
+			rows = append(rows, []string{str(profile.ProfileName), str(profile.CpuType), integer(profile.CpuCount), integer(profile.Ram), strings.Join(disks, ", ")})
+		}
+	case *vmwarev1.DirectorSitePricingInfo:
+		header = []string{"METRIC", "COUNTRY", "CURRENCY", "PRICE", "QUANTITY TIER", "DESCRIPTION"}
+		for _, metric := range result.DirectorSitePricing {
+			for _, item := range metric.PriceList {
+				for _, price := range item.Prices {
+					rows = append(rows, []string{str(metric.Metric), str(item.Country), str(item.Currency), amount(price.Price), integer(price.QuantityTier), str(metric.Description)})
+				}
+			}
+		}
+	case *vmwarev1.DirectorSitePriceQuoteResponse:
+		header = []string{"ITEM", "COUNT", "UNIT PRICE", "PRICE", "CURRENCY"}
+		if result.BaseCharge != nil {
+			rows = append(rows, []string{str(result.BaseCharge.Name), "", "", amount(result.BaseCharge.Price), str(result.BaseCharge.Currency)})
+		}
+		for _, cluster := range result.Clusters {
+			rows = append(rows, []string{str(cluster.Name), "", "", amount(cluster.Price), str(cluster.Currency)})
+			for _, item := range cluster.Items {
+				rows = append(rows, []string{"  " + str(item.Name), "", "", amount(item.Price), str(item.Currency)})
+				for _, subItem := range item.Items {
+					rows = append(rows, []string{"    " + str(subItem.Name), integer(subItem.Count), amount(subItem.Price), "", str(subItem.Currency)})
+				}
+			}
+		}
+		rows = append(rows, []string{"TOTAL", "", "", amount(result.Total), str(result.Currency)})
+	case *vmwarev1.NewPassword:
+		header, rows = []string{"PASSWORD"}, [][]string{{str(result.Password)}}
+	case *vmwarev1.SetHostsCountResponse:
+		header, rows = []string{"MESSAGE"}, [][]string{{str(result.Message)}}
+	case *vmwarev1.FileShares:
+		header, rows = []string{"STORAGE TIER", "SIZE (GB)"}, fileSharesRows(result)
+	}
+	return
+}
+
+func siteRow(site *vmwarev1.DirectorSite) []string {
+	return []string{str(site.ID), str(site.Name), str(site.Status), str(site.ResourceGroup), strconv.Itoa(len(site.Clusters)), str(site.InstanceCreated)}
+}
+
+func clusterRow(cluster *vmwarev1.Cluster) []string {
+	return []string{str(cluster.ID), str(cluster.Name), str(cluster.Location), integer(cluster.HostCount), str(cluster.HostProfile), str(cluster.Status), storageSummary(cluster.FileShares)}
+}
+
+func vdcRow(vdc *vmwarev1.VDC) []string {
+	var siteID, clusterID string
+	if vdc.DirectorSite != nil {
+		siteID = str(vdc.DirectorSite.ID)
+		if vdc.DirectorSite.Cluster != nil {
+			clusterID = str(vdc.DirectorSite.Cluster.ID)
+		}
+	}
+	var edgeTypes, publicIPs []string
+	for _, edge := range vdc.Edges {
+		edgeTypes = append(edgeTypes, str(edge.Type))
+		publicIPs = append(publicIPs, edge.PublicIps...)
+	}
+	return []string{str(vdc.ID), str(vdc.Name), str(vdc.Status), siteID, clusterID, strings.Join(edgeTypes, ","), strings.Join(publicIPs, ",")}
+}
+
+// storageSummary formats the untyped file shares of a cluster as TIER=GB pairs sorted by tier.
+func storageSummary(fileShares map[string]interface{}) string {
+	pairs := make([]string, 0, len(fileShares))
+	for tier, size := range fileShares {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", tier, size))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func fileSharesRows(fileShares *vmwarev1.FileShares) (rows [][]string) {
+	sizes := vmwarev1.NewFileShareSizes(fileShares)
+	for _, tier := range sizes.Tiers() {
+		rows = append(rows, []string{tier, strconv.FormatInt(sizes[tier], 10)})
+	}
+	return
+}
+
+func str(value *string) string {
+	return core.StringNilMapper(value)
+}
+
+func integer(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func amount(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', 2, 64)
+}
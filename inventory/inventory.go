@@ -0,0 +1,286 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package inventory : A flat inventory of the director sites, clusters and Virtual Data Centers of an account
+//
+// Collect crawls ListWorkloadDomainInstances, ListClusterInstances and ListVdcs and joins the results into one Record
+// per resource. Clusters and Virtual Data Centers carry the name of their director site, and Virtual Data Centers the
+// name and location of their cluster, so every row stands on its own. Write the records with WriteCSV, WriteJSONL or
+// WriteYAML; every format uses the columns of Columns in the same order.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// Kinds of resources in an inventory, the values of Record.Kind.
+const (
+	KindDirectorSite = "director_site"
+	KindCluster      = "cluster"
+	KindVDC          = "vdc"
+)
+
+// Record : A director site, cluster or Virtual Data Center in an inventory
+// The fields that do not apply to the kind of the record are empty. The sizes of file shares are in GB; storage
+// tiers unknown to this version of the SDK are summed into StorageOtherGB.
+type Record struct {
+	// The kind of the resource, such as KindCluster.
+	Kind string `json:"kind" yaml:"kind"`
+
+	// The director site of the resource.
+	SiteID        string `json:"site_id" yaml:"site_id"`
+	SiteName      string `json:"site_name" yaml:"site_name"`
+	ResourceGroup string `json:"resource_group" yaml:"resource_group"`
+
+	// The cluster, for clusters and Virtual Data Centers.
+	ClusterID   string `json:"cluster_id" yaml:"cluster_id"`
+	ClusterName string `json:"cluster_name" yaml:"cluster_name"`
+	Location    string `json:"location" yaml:"location"`
+
+	// The hosts, storage and billing of a cluster.
+	HostProfile               string `json:"host_profile" yaml:"host_profile"`
+	HostCount                 *int64 `json:"host_count" yaml:"host_count"`
+	BillingPlan               string `json:"billing_plan" yaml:"billing_plan"`
+	StorageType               string `json:"storage_type" yaml:"storage_type"`
+	StoragePointTwoFiveIopsGB *int64 `json:"storage_point_two_five_iops_gb" yaml:"storage_point_two_five_iops_gb"`
+	StorageTwoIopsGB          *int64 `json:"storage_two_iops_gb" yaml:"storage_two_iops_gb"`
+	StorageFourIopsGB         *int64 `json:"storage_four_iops_gb" yaml:"storage_four_iops_gb"`
+	StorageTenIopsGB          *int64 `json:"storage_ten_iops_gb" yaml:"storage_ten_iops_gb"`
+	StorageOtherGB            *int64 `json:"storage_other_gb" yaml:"storage_other_gb"`
+	StorageTotalGB            *int64 `json:"storage_total_gb" yaml:"storage_total_gb"`
+
+	// The Virtual Data Center. Edges are described as their type, followed by a slash and their size when they have one.
+	VDCID           string   `json:"vdc_id" yaml:"vdc_id"`
+	VDCName         string   `json:"vdc_name" yaml:"vdc_name"`
+	VDCType         string   `json:"vdc_type" yaml:"vdc_type"`
+	AllocationModel string   `json:"allocation_model" yaml:"allocation_model"`
+	OrgName         string   `json:"org_name" yaml:"org_name"`
+	Edges           []string `json:"edges" yaml:"edges"`
+	PublicIPs       []string `json:"public_ips" yaml:"public_ips"`
+
+	// The status of the resource and when it was created, in RFC 3339 format and UTC.
+	Status  string `json:"status" yaml:"status"`
+	Created string `json:"created" yaml:"created"`
+}
+
+// Collect : Crawl the director sites, clusters and Virtual Data Centers of the account
+// The records of each director site are followed by the records of its clusters and then of its Virtual Data
+// Centers. Director sites, clusters and Virtual Data Centers are each sorted by name and then by ID. Virtual Data
+// Centers on director sites that are not listed come last. Clusters of deleted director sites are not listed, and a
+// director site being deleted that is gone before its clusters are listed has none.
+func Collect(ctx context.Context, client vmwarev1.VmwareV1API) ([]Record, error) {
+	sites, _, err := client.ListWorkloadDomainInstancesWithContext(ctx, &vmwarev1.ListWorkloadDomainInstancesOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing director sites: %w", err)
+	}
+	vdcs, _, err := client.ListVdcsWithContext(ctx, &vmwarev1.ListVdcsOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing Virtual Data Centers: %w", err)
+	}
+
+	directorSites := append([]vmwarev1.DirectorSite(nil), sites.DirectorSites...)
+	sort.SliceStable(directorSites, func(i, j int) bool {
+		return less(directorSites[i].Name, directorSites[i].ID, directorSites[j].Name, directorSites[j].ID)
+	})
+	sortVDCs(vdcs.Vdcs)
+
+	var records []Record
+	listed := make(map[string]bool)
+	for i := range directorSites {
+		site := &directorSites[i]
+		siteID := core.StringNilMapper(site.ID)
+		listed[siteID] = true
+		records = append(records, siteRecord(site))
+
+		clusters, err := listClusters(ctx, client, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing clusters of director site %s: %w", siteID, err)
+		}
+		sortClusters(clusters)
+		byID := make(map[string]*vmwarev1.Cluster)
+		for j := range clusters {
+			cluster := &clusters[j]
+			byID[core.StringNilMapper(cluster.ID)] = cluster
+			records = append(records, clusterRecord(site, cluster))
+		}
+
+		for j := range vdcs.Vdcs {
+			vdc := &vdcs.Vdcs[j]
+			if vdcSiteID(vdc) == siteID {
+				records = append(records, vdcRecord(site, byID[vdcClusterID(vdc)], vdc))
+			}
+		}
+	}
+	for j := range vdcs.Vdcs {
+		vdc := &vdcs.Vdcs[j]
+		if !listed[vdcSiteID(vdc)] {
+			records = append(records, vdcRecord(nil, nil, vdc))
+		}
+	}
+	return records, nil
+}
+
+// listClusters returns the clusters of the director site. A site that is being deleted may be gone by the time its
+// clusters are listed, so it has no clusters then rather than failing the whole crawl.
+func listClusters(ctx context.Context, client vmwarev1.VmwareV1API, site *vmwarev1.DirectorSite) ([]vmwarev1.Cluster, error) {
+	if core.StringNilMapper(site.Status) == vmwarev1.DirectorSite_Status_Deleted {
+		return nil, nil
+	}
+	clusters, _, err := client.ListClusterInstancesWithContext(ctx, &vmwarev1.ListClusterInstancesOptions{SiteID: site.ID})
+	if err != nil {
+		if core.StringNilMapper(site.Status) == vmwarev1.DirectorSite_Status_Deleting && vmwarev1.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return clusters.Clusters, nil
+}
+
+// newRecord returns an empty record of the kind. List fields are empty rather than nil, so every record is written
+// the same way.
+func newRecord(kind string) Record {
+	return Record{Kind: kind, Edges: []string{}, PublicIPs: []string{}}
+}
+
+func siteRecord(site *vmwarev1.DirectorSite) Record {
+	record := newRecord(KindDirectorSite)
+	setSite(&record, site)
+	record.Status = core.StringNilMapper(site.Status)
+	record.Created = formatTimestamp(site.InstanceCreated)
+	return record
+}
+
+func clusterRecord(site *vmwarev1.DirectorSite, cluster *vmwarev1.Cluster) Record {
+	record := newRecord(KindCluster)
+	setSite(&record, site)
+	setCluster(&record, cluster)
+	record.HostProfile = core.StringNilMapper(cluster.HostProfile)
+	record.HostCount = cluster.HostCount
+	record.BillingPlan = core.StringNilMapper(cluster.BillingPlan)
+	record.StorageType = core.StringNilMapper(cluster.StorageType)
+	record.Status = core.StringNilMapper(cluster.Status)
+	record.Created = formatTimestamp(cluster.InstanceCreated)
+
+	// When a size is not a whole number of GB, the storage columns are left empty rather than dropping the cluster
+	// from the inventory.
+	sizes, err := cluster.FileShareSizes()
+	if err != nil {
+		return record
+	}
+	size := func(tier string) *int64 {
+		return core.Int64Ptr(sizes[tier])
+	}
+	record.StoragePointTwoFiveIopsGB = size(vmwarev1.StorageTierPointTwoFiveIopsGB)
+	record.StorageTwoIopsGB = size(vmwarev1.StorageTierTwoIopsGB)
+	record.StorageFourIopsGB = size(vmwarev1.StorageTierFourIopsGB)
+	record.StorageTenIopsGB = size(vmwarev1.StorageTierTenIopsGB)
+	record.StorageOtherGB = core.Int64Ptr(sizes.Unknown().Total())
+	record.StorageTotalGB = core.Int64Ptr(sizes.Total())
+	return record
+}
+
+// vdcRecord builds the record of a Virtual Data Center. The site and cluster are nil when they are not listed.
+func vdcRecord(site *vmwarev1.DirectorSite, cluster *vmwarev1.Cluster, vdc *vmwarev1.VDC) Record {
+	record := newRecord(KindVDC)
+	record.SiteID = vdcSiteID(vdc)
+	record.ClusterID = vdcClusterID(vdc)
+	if site != nil {
+		setSite(&record, site)
+	}
+	if cluster != nil {
+		setCluster(&record, cluster)
+	}
+	record.VDCID = core.StringNilMapper(vdc.ID)
+	record.VDCName = core.StringNilMapper(vdc.Name)
+	record.VDCType = core.StringNilMapper(vdc.Type)
+	record.AllocationModel = core.StringNilMapper(vdc.AllocationModel)
+	record.OrgName = core.StringNilMapper(vdc.OrgName)
+	record.Status = core.StringNilMapper(vdc.Status)
+	for _, edge := range vdc.Edges {
+		description := core.StringNilMapper(edge.Type)
+		if edge.Size != nil {
+			description += "/" + *edge.Size
+		}
+		record.Edges = append(record.Edges, description)
+		record.PublicIPs = append(record.PublicIPs, edge.PublicIps...)
+	}
+	if vdc.CreatedTime != nil {
+		record.Created = time.Time(*vdc.CreatedTime).UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+func setSite(record *Record, site *vmwarev1.DirectorSite) {
+	record.SiteID = core.StringNilMapper(site.ID)
+	record.SiteName = core.StringNilMapper(site.Name)
+	record.ResourceGroup = core.StringNilMapper(site.ResourceGroup)
+}
+
+func setCluster(record *Record, cluster *vmwarev1.Cluster) {
+	record.ClusterID = core.StringNilMapper(cluster.ID)
+	record.ClusterName = core.StringNilMapper(cluster.Name)
+	record.Location = core.StringNilMapper(cluster.Location)
+}
+
+// formatTimestamp normalizes a timestamp of the service to RFC 3339 in UTC. Timestamps that cannot be parsed are kept
+// as they are.
+func formatTimestamp(value *string) string {
+	parsed, err := vmwarev1.ParseTimestamp(core.StringNilMapper(value))
+	if err != nil {
+		return core.StringNilMapper(value)
+	}
+	return parsed.UTC().Format(time.RFC3339)
+}
+
+func vdcSiteID(vdc *vmwarev1.VDC) string {
+	if vdc.DirectorSite == nil {
+		return ""
+	}
+	return core.StringNilMapper(vdc.DirectorSite.ID)
+}
+
+func vdcClusterID(vdc *vmwarev1.VDC) string {
+	if vdc.DirectorSite == nil || vdc.DirectorSite.Cluster == nil {
+		return ""
+	}
+	return core.StringNilMapper(vdc.DirectorSite.Cluster.ID)
+}
+
+func sortClusters(clusters []vmwarev1.Cluster) {
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return less(clusters[i].Name, clusters[i].ID, clusters[j].Name, clusters[j].ID)
+	})
+}
+
+func sortVDCs(vdcs []vmwarev1.VDC) {
+	sort.SliceStable(vdcs, func(i, j int) bool {
+		return less(vdcs[i].Name, vdcs[i].ID, vdcs[j].Name, vdcs[j].ID)
+	})
+}
+
+// less orders resources by name and then by ID.
+func less(name1 *string, id1 *string, name2 *string, id2 *string) bool {
+	if core.StringNilMapper(name1) != core.StringNilMapper(name2) {
+		return core.StringNilMapper(name1) < core.StringNilMapper(name2)
+	}
+	return core.StringNilMapper(id1) < core.StringNilMapper(id2)
+}
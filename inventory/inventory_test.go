@@ -0,0 +1,296 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var now = time.Date(2022, 11, 15, 10, 30, 0, 0, time.UTC)
+
+// newInventory creates two director sites, the first with two clusters and a VDC, and collects their inventory.
+func newInventory(t *testing.T) (*vmwarev1fake.Server, []Record) {
+	server := vmwarev1fake.NewServer(&vmwarev1fake.ServerOptions{Now: func() time.Time { return now }})
+	t.Cleanup(server.Close)
+	service, err := server.NewClient()
+	require.Nil(t, err)
+
+	newCluster := func(name string, location string, hostCount int64, fileShares *vmwarev1.FileShares) vmwarev1.ClusterOrderInfo {
+		cluster, err := service.NewClusterOrderInfo(name, location, hostCount, fileShares, vmwarev1fake.HostProfile192GB)
+		require.Nil(t, err)
+		return *cluster
+	}
+	site, _, err := service.CreateWorkloadDomain(service.NewCreateWorkloadDomainOptions("site2", "Default", []vmwarev1.ClusterOrderInfo{
+		newCluster("cluster2", "dal12", 3, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}),
+		newCluster("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(500), STORAGETENIOPSGB: core.Int64Ptr(250)}),
+	}))
+	require.Nil(t, err)
+	_, _, err = service.CreateWorkloadDomain(service.NewCreateWorkloadDomainOptions("site1", "Finance", []vmwarev1.ClusterOrderInfo{
+		newCluster("cluster1", "fra02", 2, &vmwarev1.FileShares{STORAGEFOURIOPSGB: core.Int64Ptr(2000)}),
+	}))
+	require.Nil(t, err)
+	server.CompletePending()
+
+	var clusterID string
+	for _, cluster := range site.Clusters {
+		if *cluster.Name == "cluster1" {
+			clusterID = *cluster.ID
+		}
+	}
+	cluster, err := service.NewVDCDirectorSiteCluster(clusterID)
+	require.Nil(t, err)
+	directorSite, err := service.NewNewVDCDirectorSite(*site.ID, cluster)
+	require.Nil(t, err)
+	_, _, err = service.CreateVdc(service.NewCreateVdcOptions("vdc1", directorSite))
+	require.Nil(t, err)
+	server.CompletePending()
+
+	records, err := Collect(context.Background(), service)
+	require.Nil(t, err)
+	return server, records
+}
+
+func TestCollect(t *testing.T) {
+	server, records := newInventory(t)
+	assert.Equal(t, 1, server.RequestCount("ListWorkloadDomainInstances"))
+	assert.Equal(t, 2, server.RequestCount("ListClusterInstances"))
+	assert.Equal(t, 1, server.RequestCount("ListVdcs"))
+
+	var rows []string
+	for _, record := range records {
+		rows = append(rows, record.Kind+" "+record.SiteName+"/"+record.ClusterName+"/"+record.VDCName)
+	}
+	assert.Equal(t, []string{
+		"director_site site1//",
+		"cluster site1/cluster1/",
+		"director_site site2//",
+		"cluster site2/cluster1/",
+		"cluster site2/cluster2/",
+		"vdc site2/cluster1/vdc1",
+	}, rows)
+
+	site := records[2]
+	assert.Equal(t, "Default", site.ResourceGroup)
+	assert.Equal(t, "2022-11-15T10:30:00Z", site.Created)
+	assert.Nil(t, site.HostCount)
+	assert.Equal(t, []string{}, site.Edges)
+
+	cluster := records[3]
+	assert.Equal(t, site.SiteID, cluster.SiteID)
+	assert.Equal(t, "dal10", cluster.Location)
+	assert.Equal(t, vmwarev1fake.HostProfile192GB, cluster.HostProfile)
+	assert.Equal(t, int64(2), *cluster.HostCount)
+	assert.Equal(t, vmwarev1.Cluster_BillingPlan_Monthly, cluster.BillingPlan)
+	assert.Equal(t, int64(0), *cluster.StoragePointTwoFiveIopsGB)
+	assert.Equal(t, int64(500), *cluster.StorageTwoIopsGB)
+	assert.Equal(t, int64(250), *cluster.StorageTenIopsGB)
+	assert.Equal(t, int64(0), *cluster.StorageOtherGB)
+	assert.Equal(t, int64(750), *cluster.StorageTotalGB)
+
+	vdc := records[5]
+	assert.Equal(t, site.SiteID, vdc.SiteID)
+	assert.Equal(t, cluster.ClusterID, vdc.ClusterID)
+	assert.Equal(t, "dal10", vdc.Location)
+	assert.Equal(t, "org-"+vdc.VDCID, vdc.OrgName)
+	assert.Equal(t, []string{vmwarev1.Edge_Type_Shared}, vdc.Edges)
+	assert.Len(t, vdc.PublicIPs, 1)
+	assert.Nil(t, vdc.HostCount)
+}
+
+func TestCollectError(t *testing.T) {
+	server := vmwarev1fake.NewServer(nil)
+	t.Cleanup(server.Close)
+	service, err := server.NewClient()
+	require.Nil(t, err)
+	server.InjectFault("ListVdcs", vmwarev1fake.Fault{StatusCode: 500, Times: 1})
+
+	_, err = Collect(context.Background(), service)
+	require.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "listing Virtual Data Centers: "), err.Error())
+}
+
+func TestCollectDeletedSites(t *testing.T) {
+	server := vmwarev1fake.NewServer(&vmwarev1fake.ServerOptions{TransitionDelay: time.Hour})
+	t.Cleanup(server.Close)
+	service, err := server.NewClient()
+	require.Nil(t, err)
+	cluster, err := service.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(500)}, vmwarev1fake.HostProfile192GB)
+	require.Nil(t, err)
+	site, _, err := service.CreateWorkloadDomain(service.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+	require.Nil(t, err)
+	server.CompletePending()
+	_, _, err = service.DeleteWorkloadDomain(service.NewDeleteWorkloadDomainOptions(*site.ID))
+	require.Nil(t, err)
+
+	// The director site is gone by the time its clusters are listed.
+	server.InjectFault("ListClusterInstances", vmwarev1fake.Fault{StatusCode: 404, Times: 1})
+	records, err := Collect(context.Background(), service)
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, KindDirectorSite, records[0].Kind)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Deleting, records[0].Status)
+
+	// The clusters of a deleted director site are not listed.
+	server.CompletePending()
+	records, err = Collect(context.Background(), service)
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Deleted, records[0].Status)
+	assert.Equal(t, 1, server.RequestCount("ListClusterInstances"))
+}
+
+func TestValuesFollowColumns(t *testing.T) {
+	record := Record{Kind: KindCluster, SiteName: "site1", HostCount: core.Int64Ptr(3), PublicIPs: []string{"192.0.2.1", "192.0.2.2"}, Created: "2022-11-15T10:30:00Z"}
+	values := record.Values()
+	require.Len(t, values, len(Columns))
+	byColumn := make(map[string]string)
+	for i, column := range Columns {
+		byColumn[column] = values[i]
+	}
+	assert.Equal(t, KindCluster, byColumn["kind"])
+	assert.Equal(t, "site1", byColumn["site_name"])
+	assert.Equal(t, "3", byColumn["host_count"])
+	assert.Equal(t, "", byColumn["storage_total_gb"])
+	assert.Equal(t, "192.0.2.1;192.0.2.2", byColumn["public_ips"])
+	assert.Equal(t, "2022-11-15T10:30:00Z", byColumn["created"])
+}
+
+func TestClusterRecordFractionalFileShares(t *testing.T) {
+	site := &vmwarev1.DirectorSite{ID: core.StringPtr("site"), Name: core.StringPtr("site1")}
+	cluster := &vmwarev1.Cluster{
+		ID:         core.StringPtr("cluster"),
+		Name:       core.StringPtr("cluster1"),
+		HostCount:  core.Int64Ptr(2),
+		FileShares: map[string]interface{}{vmwarev1.StorageTierTwoIopsGB: 500.5, vmwarev1.StorageTierTenIopsGB: 250.0},
+	}
+
+	record := clusterRecord(site, cluster)
+	assert.Equal(t, "cluster1", record.ClusterName)
+	assert.Equal(t, int64(2), *record.HostCount)
+	assert.Nil(t, record.StoragePointTwoFiveIopsGB)
+	assert.Nil(t, record.StorageTwoIopsGB)
+	assert.Nil(t, record.StorageFourIopsGB)
+	assert.Nil(t, record.StorageTenIopsGB)
+	assert.Nil(t, record.StorageOtherGB)
+	assert.Nil(t, record.StorageTotalGB)
+
+	values := record.Values()
+	for i, column := range Columns {
+		if strings.HasPrefix(column, "storage_") && strings.HasSuffix(column, "_gb") {
+			assert.Equal(t, "", values[i], column)
+		}
+	}
+}
+
+func TestColumnsMatchRecord(t *testing.T) {
+	recordType := reflect.TypeOf(Record{})
+	require.Equal(t, recordType.NumField(), len(Columns))
+	for i, column := range Columns {
+		assert.Equal(t, column, recordType.Field(i).Tag.Get("json"))
+		assert.Equal(t, column, recordType.Field(i).Tag.Get("yaml"))
+	}
+	assert.Len(t, (&Record{}).Values(), len(Columns))
+}
+
+func TestWriteCSV(t *testing.T) {
+	_, records := newInventory(t)
+	var buf bytes.Buffer
+	require.Nil(t, Write(&buf, FormatCSV, records))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.Nil(t, err)
+	require.Len(t, rows, len(records)+1)
+	assert.Equal(t, Columns, rows[0])
+
+	row := func(i int) map[string]string {
+		values := make(map[string]string)
+		for j, column := range Columns {
+			values[column] = rows[i+1][j]
+		}
+		return values
+	}
+	assert.Equal(t, "", row(2)["host_count"])
+	assert.Equal(t, "2", row(3)["host_count"])
+	assert.Equal(t, "750", row(3)["storage_total_gb"])
+	assert.Equal(t, "shared", row(5)["edges"])
+	assert.Equal(t, "vdc1", row(5)["vdc_name"])
+}
+
+func TestWriteJSONL(t *testing.T) {
+	_, records := newInventory(t)
+	var buf bytes.Buffer
+	require.Nil(t, Write(&buf, FormatJSONL, records))
+
+	scanner := bufio.NewScanner(&buf)
+	var lines int
+	for scanner.Scan() {
+		var keys []string
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		_, err := decoder.Token()
+		require.Nil(t, err)
+		for decoder.More() {
+			key, err := decoder.Token()
+			require.Nil(t, err)
+			keys = append(keys, key.(string))
+			var value interface{}
+			require.Nil(t, decoder.Decode(&value))
+		}
+		assert.Equal(t, Columns, keys)
+		lines++
+	}
+	assert.Equal(t, len(records), lines)
+}
+
+func TestWriteYAML(t *testing.T) {
+	_, records := newInventory(t)
+	var buf bytes.Buffer
+	require.Nil(t, Write(&buf, FormatYAML, records))
+
+	var document yaml.Node
+	require.Nil(t, yaml.Unmarshal(buf.Bytes(), &document))
+	sequence := document.Content[0]
+	require.Len(t, sequence.Content, len(records))
+	var keys []string
+	for i := 0; i < len(sequence.Content[0].Content); i += 2 {
+		keys = append(keys, sequence.Content[0].Content[i].Value)
+	}
+	assert.Equal(t, Columns, keys)
+
+	var decoded []Record
+	require.Nil(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, records[3], decoded[3])
+	assert.Equal(t, records[5], decoded[5])
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	assert.NotNil(t, Write(&bytes.Buffer{}, "xml", nil))
+}
@@ -0,0 +1,157 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by Write.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatYAML  = "yaml"
+)
+
+// Columns lists the columns of an inventory in the order they are written. They are the JSON and YAML keys of Record.
+var Columns = []string{
+	"kind",
+	"site_id",
+	"site_name",
+	"resource_group",
+	"cluster_id",
+	"cluster_name",
+	"location",
+	"host_profile",
+	"host_count",
+	"billing_plan",
+	"storage_type",
+	"storage_point_two_five_iops_gb",
+	"storage_two_iops_gb",
+	"storage_four_iops_gb",
+	"storage_ten_iops_gb",
+	"storage_other_gb",
+	"storage_total_gb",
+	"vdc_id",
+	"vdc_name",
+	"vdc_type",
+	"allocation_model",
+	"org_name",
+	"edges",
+	"public_ips",
+	"status",
+	"created",
+}
+
+// listSeparator separates the values of list columns, such as public_ips, in CSV.
+const listSeparator = ";"
+
+// Write : Write the records in the format, one of FormatCSV, FormatJSONL and FormatYAML
+func Write(w io.Writer, format string, records []Record) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, records)
+	case FormatJSONL:
+		return WriteJSONL(w, records)
+	case FormatYAML:
+		return WriteYAML(w, records)
+	}
+	return fmt.Errorf("unknown inventory format %q; use csv, jsonl or yaml", format)
+}
+
+// WriteCSV : Write the records as CSV with a header row of Columns
+// Empty fields are written as empty cells, and the values of list columns are separated by semicolons.
+func WriteCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(Columns); err != nil {
+		return err
+	}
+	for i := range records {
+		if err := writer.Write(records[i].Values()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSONL : Write the records as JSON Lines, one object per record with the keys of Columns in order
+// Empty numbers are written as null.
+func WriteJSONL(w io.Writer, records []Record) error {
+	encoder := json.NewEncoder(w)
+	for i := range records {
+		if err := encoder.Encode(records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteYAML : Write the records as a YAML sequence of mappings with the keys of Columns in order
+func WriteYAML(w io.Writer, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(records); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Values returns the values of the record in the order of Columns, as written by WriteCSV.
+func (record *Record) Values() []string {
+	value := reflect.ValueOf(record).Elem()
+	values := make([]string, len(Columns))
+	for i, column := range Columns {
+		index, ok := columnFields[column]
+		if !ok {
+			continue
+		}
+		switch field := value.Field(index).Interface().(type) {
+		case string:
+			values[i] = field
+		case *int64:
+			if field != nil {
+				values[i] = strconv.FormatInt(*field, 10)
+			}
+		case []string:
+			values[i] = strings.Join(field, listSeparator)
+		}
+	}
+	return values
+}
+
+// columnFields maps the columns to the index of their field in Record, found by JSON key, so Values always follows
+// Columns.
+var columnFields = func() map[string]int {
+	recordType := reflect.TypeOf(Record{})
+	fields := make(map[string]int, recordType.NumField())
+	for i := 0; i < recordType.NumField(); i++ {
+		fields[recordType.Field(i).Tag.Get("json")] = i
+	}
+	return fields
+}()
@@ -0,0 +1,250 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// Client : The operations of the VMware as a Service API used by Reconciler
+// *vmwarev1.VmwareV1 implements Client.
+type Client interface {
+	vmwarev1.VmwareV1API
+	WaitForDirectorSite(ctx context.Context, siteID string, opts *vmwarev1.WaitOptions) (*vmwarev1.DirectorSite, error)
+	WaitForCluster(ctx context.Context, siteID string, clusterID string, opts *vmwarev1.WaitOptions) (*vmwarev1.Cluster, error)
+	ResizeCluster(ctx context.Context, siteID string, clusterID string, count int64, opts *vmwarev1.WaitOptions) (*vmwarev1.Cluster, error)
+	WaitForVdc(ctx context.Context, vdcID string, opts *vmwarev1.WaitOptions) (*vmwarev1.VDC, error)
+}
+
+var _ Client = (*vmwarev1.VmwareV1)(nil)
+
+// ReconcilerOptions : Options for a Reconciler
+type ReconcilerOptions struct {
+	// How Apply waits for each step to settle. Target and failed statuses are set by Apply for each step, so only the
+	// intervals, timeout and callback are used. Defaults to the defaults of vmwarev1.WaitOptions.
+	Wait *vmwarev1.WaitOptions
+
+	// Invoked before Apply runs each step.
+	OnStep func(step Step)
+}
+
+// Reconciler : Plan and apply the changes that converge live director sites with desired states
+type Reconciler struct {
+	client  Client
+	options ReconcilerOptions
+}
+
+// NewReconciler : Construct a Reconciler
+func NewReconciler(client Client, options *ReconcilerOptions) *Reconciler {
+	reconciler := &Reconciler{client: client}
+	if options != nil {
+		reconciler.options = *options
+	}
+	return reconciler
+}
+
+// StepError is returned by Apply when a step fails. The steps before it were applied.
+type StepError struct {
+	// The index of the failed step in the plan.
+	Index int
+
+	// The failed step.
+	Step Step
+
+	// The error returned by the service or the waiter.
+	Err error
+}
+
+// Error returns the error message.
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d (%s) failed: %s", e.Index+1, e.Step.String(), e.Err.Error())
+}
+
+// Unwrap returns the cause of the failure.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Apply : Run the steps of a plan in order
+// Each step waits for the resources it changes to settle before the next step starts, so later steps never run
+// against a director site that is still being created or updated. IDs of resources created by earlier steps are
+// resolved by name. A *StepError is returned for the first step that fails.
+func (reconciler *Reconciler) Apply(ctx context.Context, plan *Plan) (err error) {
+	err = core.ValidateNotNil(plan, "plan cannot be nil")
+	if err != nil {
+		return
+	}
+	run := &applyRun{reconciler: reconciler, clusterIDs: make(map[string]string)}
+	for i, step := range plan.Steps {
+		if reconciler.options.OnStep != nil {
+			reconciler.options.OnStep(step)
+		}
+		if err = run.apply(ctx, step); err != nil {
+			return &StepError{Index: i, Step: step, Err: err}
+		}
+	}
+	return
+}
+
+// Reconcile : Plan and, unless planOnly is set, apply the changes for the desired state
+// The plan is returned in both modes, together with any error of the planning or applying.
+func (reconciler *Reconciler) Reconcile(ctx context.Context, desired *DesiredState, planOnly bool) (plan *Plan, err error) {
+	plan, err = reconciler.Plan(ctx, desired)
+	if err != nil || planOnly {
+		return
+	}
+	err = reconciler.Apply(ctx, plan)
+	return
+}
+
+// applyRun : The state of a single Apply
+type applyRun struct {
+	reconciler *Reconciler
+
+	// The ID of the director site created by the plan, and of its clusters by name.
+	siteID     string
+	clusterIDs map[string]string
+}
+
+func (run *applyRun) apply(ctx context.Context, step Step) error {
+	client := run.reconciler.client
+	switch step.Operation {
+	case OperationCreateWorkloadDomain:
+		return run.createWorkloadDomain(ctx, step)
+
+	case OperationSetHostsCount:
+		_, err := client.ResizeCluster(ctx, step.SiteID, step.ClusterID, step.HostCount, run.wait(vmwarev1.DirectorSite_Status_Readytouse, vmwarev1.DirectorSite_Status_Deleting, vmwarev1.DirectorSite_Status_Deleted))
+		if errors.Is(err, vmwarev1.ErrNoOpResize) {
+			return nil
+		}
+		return err
+
+	case OperationSetFileShares:
+		setOptions := &vmwarev1.SetFileSharesOptions{
+			SiteID:                    core.StringPtr(step.SiteID),
+			ClusterID:                 core.StringPtr(step.ClusterID),
+			STORAGEPOINTTWOFIVEIOPSGB: step.FileShares.STORAGEPOINTTWOFIVEIOPSGB,
+			STORAGETWOIOPSGB:          step.FileShares.STORAGETWOIOPSGB,
+			STORAGEFOURIOPSGB:         step.FileShares.STORAGEFOURIOPSGB,
+			STORAGETENIOPSGB:          step.FileShares.STORAGETENIOPSGB,
+		}
+		if _, _, err := client.SetFileSharesWithContext(ctx, setOptions); err != nil {
+			return err
+		}
+		_, err := client.WaitForCluster(ctx, step.SiteID, step.ClusterID, run.wait(vmwarev1.DirectorSite_Status_Readytouse, vmwarev1.DirectorSite_Status_Deleting, vmwarev1.DirectorSite_Status_Deleted))
+		return err
+
+	case OperationDeleteVdc:
+		if _, _, err := client.DeleteVdcWithContext(ctx, &vmwarev1.DeleteVdcOptions{VdcID: core.StringPtr(step.VDCID)}); err != nil {
+			return err
+		}
+		_, err := client.WaitForVdc(ctx, step.VDCID, run.wait(vmwarev1.VDC_Status_Deleted, vmwarev1.VDC_Status_Failed))
+		return err
+
+	case OperationCreateVdc:
+		return run.createVdc(ctx, step)
+	}
+	return fmt.Errorf("unknown operation %s", step.Operation)
+}
+
+func (run *applyRun) createWorkloadDomain(ctx context.Context, step Step) error {
+	client := run.reconciler.client
+	clusters := make([]vmwarev1.ClusterOrderInfo, len(step.Site.Clusters))
+	for i, cluster := range step.Site.Clusters {
+		fileShares, err := cluster.fileShares()
+		if err != nil {
+			return err
+		}
+		clusters[i] = vmwarev1.ClusterOrderInfo{
+			Name:        core.StringPtr(cluster.Name),
+			Location:    core.StringPtr(cluster.Location),
+			HostCount:   core.Int64Ptr(cluster.HostCount),
+			FileShares:  fileShares,
+			HostProfile: core.StringPtr(cluster.HostProfile),
+		}
+	}
+	site, _, err := client.CreateWorkloadDomainWithContext(ctx, &vmwarev1.CreateWorkloadDomainOptions{
+		Name:          core.StringPtr(step.Site.Name),
+		ResourceGroup: core.StringPtr(step.Site.ResourceGroup),
+		Clusters:      clusters,
+	})
+	if err != nil {
+		return err
+	}
+	site, err = client.WaitForDirectorSite(ctx, core.StringNilMapper(site.ID), run.wait(vmwarev1.DirectorSite_Status_Readytouse, vmwarev1.DirectorSite_Status_Deleting, vmwarev1.DirectorSite_Status_Deleted))
+	if err != nil {
+		return err
+	}
+	run.siteID = core.StringNilMapper(site.ID)
+	for _, cluster := range site.Clusters {
+		run.clusterIDs[core.StringNilMapper(cluster.Name)] = core.StringNilMapper(cluster.ID)
+	}
+	return nil
+}
+
+func (run *applyRun) createVdc(ctx context.Context, step Step) error {
+	client := run.reconciler.client
+	siteID, clusterID := step.SiteID, step.ClusterID
+	if siteID == "" {
+		siteID = run.siteID
+	}
+	if clusterID == "" {
+		clusterID = run.clusterIDs[step.ClusterName]
+	}
+	if siteID == "" || clusterID == "" {
+		return fmt.Errorf("cluster %s/%s does not exist", step.SiteName, step.ClusterName)
+	}
+
+	createOptions := &vmwarev1.CreateVdcOptions{
+		Name: core.StringPtr(step.VDC.Name),
+		DirectorSite: &vmwarev1.NewVDCDirectorSite{
+			ID:      core.StringPtr(siteID),
+			Cluster: &vmwarev1.VDCDirectorSiteCluster{ID: core.StringPtr(clusterID)},
+		},
+	}
+	if step.VDC.Edge != nil {
+		createOptions.Edge = &vmwarev1.NewVDCEdge{Type: core.StringPtr(step.VDC.Edge.Type)}
+		if step.VDC.Edge.Size != "" {
+			createOptions.Edge.Size = core.StringPtr(step.VDC.Edge.Size)
+		}
+	}
+	if step.VDC.ResourceGroupID != "" {
+		createOptions.ResourceGroup = &vmwarev1.NewVDCResourceGroup{ID: core.StringPtr(step.VDC.ResourceGroupID)}
+	}
+	vdc, _, err := client.CreateVdcWithContext(ctx, createOptions)
+	if err != nil {
+		return err
+	}
+	_, err = client.WaitForVdc(ctx, core.StringNilMapper(vdc.ID), run.wait(vmwarev1.VDC_Status_Readytouse, vmwarev1.VDC_Status_Failed))
+	return err
+}
+
+// wait returns the wait options of the reconciler that succeed on the target status and fail on the other statuses.
+func (run *applyRun) wait(target string, failed ...string) *vmwarev1.WaitOptions {
+	opts := vmwarev1.WaitOptions{}
+	if run.reconciler.options.Wait != nil {
+		opts = *run.reconciler.options.Wait
+	}
+	opts.TargetStatuses = []string{target}
+	opts.FailedStatuses = failed
+	return &opts
+}
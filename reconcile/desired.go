@@ -0,0 +1,183 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package reconcile : Reconcile director sites, clusters and Virtual Data Centers with a desired state
+//
+// A DesiredState document describes one director site: its clusters with their host counts and file shares, and the
+// Virtual Data Centers deployed on them. Reconciler.Plan compares the document with the live state returned by
+// ListWorkloadDomainInstances and ListVdcs and computes the calls that converge them. Reconciler.Apply runs those
+// calls in order (the site, then cluster changes, then Virtual Data Centers), waiting for each change to settle before
+// starting the next one.
+package reconcile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"gopkg.in/yaml.v3"
+)
+
+// DesiredState : The desired state of a director site
+type DesiredState struct {
+	// The name of the director site. Director sites are matched by name.
+	Name string `json:"name" yaml:"name"`
+
+	// The name or ID of the resource group of the director site.
+	ResourceGroup string `json:"resource_group" yaml:"resource_group"`
+
+	// The clusters of the director site.
+	Clusters []DesiredCluster `json:"clusters" yaml:"clusters"`
+
+	// The Virtual Data Centers deployed on the director site.
+	VDCs []DesiredVDC `json:"vdcs,omitempty" yaml:"vdcs,omitempty"`
+}
+
+// DesiredCluster : The desired state of a cluster
+type DesiredCluster struct {
+	// The name of the cluster. Clusters are matched by name.
+	Name string `json:"name" yaml:"name"`
+
+	// The data center of the cluster. Only used when the director site is created.
+	Location string `json:"location" yaml:"location"`
+
+	// The host profile of the cluster. Only used when the director site is created.
+	HostProfile string `json:"host_profile" yaml:"host_profile"`
+
+	// The number of hosts of the cluster.
+	HostCount int64 `json:"host_count" yaml:"host_count"`
+
+	// The size in GB of each storage tier, keyed by the tiers of vmwarev1.FileShares such as
+	// vmwarev1.StorageTierTwoIopsGB. Tiers that are not listed are left unchanged.
+	FileShares map[string]int64 `json:"file_shares" yaml:"file_shares"`
+}
+
+// DesiredVDC : The desired state of a Virtual Data Center
+type DesiredVDC struct {
+	// The name of the Virtual Data Center. Virtual Data Centers are matched by name within the director site.
+	Name string `json:"name" yaml:"name"`
+
+	// The name of the cluster the Virtual Data Center is deployed on.
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// The networking edge of the Virtual Data Center. Defaults to the edge chosen by the service.
+	Edge *DesiredEdge `json:"edge,omitempty" yaml:"edge,omitempty"`
+
+	// The ID of the resource group of the Virtual Data Center. Defaults to the default resource group of the account.
+	ResourceGroupID string `json:"resource_group_id,omitempty" yaml:"resource_group_id,omitempty"`
+}
+
+// DesiredEdge : The desired networking edge of a Virtual Data Center
+type DesiredEdge struct {
+	// The type of the edge, vmwarev1.NewVDCEdge_Type_Shared or vmwarev1.NewVDCEdge_Type_Dedicated.
+	Type string `json:"type" yaml:"type"`
+
+	// The size of a dedicated edge, such as vmwarev1.NewVDCEdge_Size_Medium.
+	Size string `json:"size,omitempty" yaml:"size,omitempty"`
+}
+
+// ParseDesiredState : Parse a desired state document
+// The document is YAML or JSON. The parsed state is validated before it is returned.
+func ParseDesiredState(r io.Reader) (*DesiredState, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	desired := &DesiredState{}
+	if err = decoder.Decode(desired); err != nil {
+		return nil, fmt.Errorf("invalid desired state document: %s", err.Error())
+	}
+	if err = desired.Validate(); err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+// Validate : Check that the desired state is complete and consistent
+func (desired *DesiredState) Validate() error {
+	var problems []string
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	if desired.Name == "" {
+		problem("name is required")
+	}
+	if desired.ResourceGroup == "" {
+		problem("resource_group is required")
+	}
+	if len(desired.Clusters) == 0 {
+		problem("at least one cluster is required")
+	}
+	clusters := make(map[string]bool)
+	for i, cluster := range desired.Clusters {
+		if cluster.Name == "" {
+			problem("clusters[%d].name is required", i)
+		} else if clusters[cluster.Name] {
+			problem("clusters[%d].name %q is not unique", i, cluster.Name)
+		}
+		clusters[cluster.Name] = true
+		if cluster.HostCount < 1 {
+			problem("clusters[%d].host_count must be at least 1", i)
+		}
+		if _, err := cluster.fileShares(); err != nil {
+			problem("clusters[%d].file_shares: %s", i, err.Error())
+		}
+	}
+	vdcs := make(map[string]bool)
+	for i, vdc := range desired.VDCs {
+		if vdc.Name == "" {
+			problem("vdcs[%d].name is required", i)
+		} else if vdcs[vdc.Name] {
+			problem("vdcs[%d].name %q is not unique", i, vdc.Name)
+		}
+		vdcs[vdc.Name] = true
+		if !clusters[vdc.Cluster] {
+			problem("vdcs[%d].cluster %q is not a cluster of the director site", i, vdc.Cluster)
+		}
+		if vdc.Edge != nil && vdc.Edge.Type != vmwarev1.NewVDCEdge_Type_Shared && vdc.Edge.Type != vmwarev1.NewVDCEdge_Type_Dedicated {
+			problem("vdcs[%d].edge.type must be %s or %s", i, vmwarev1.NewVDCEdge_Type_Shared, vmwarev1.NewVDCEdge_Type_Dedicated)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid desired state: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// fileShares converts the file shares of the cluster to the model of the service.
+func (cluster *DesiredCluster) fileShares() (*vmwarev1.FileShares, error) {
+	return toFileShares(cluster.FileShares)
+}
+
+// toFileShares converts sizes keyed by storage tier to a vmwarev1.FileShares, rejecting unknown tiers.
+func toFileShares(sizes map[string]int64) (*vmwarev1.FileShares, error) {
+	buf, err := json.Marshal(sizes)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.DisallowUnknownFields()
+	fileShares := &vmwarev1.FileShares{}
+	if err = decoder.Decode(fileShares); err != nil {
+		return nil, err
+	}
+	return fileShares, nil
+}
@@ -0,0 +1,369 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// Operations planned by Reconciler.Plan, named after the VmwareV1 methods that perform them.
+const (
+	OperationCreateWorkloadDomain = "CreateWorkloadDomain"
+	OperationSetHostsCount        = "SetHostsCount"
+	OperationSetFileShares        = "SetFileShares"
+	OperationCreateVdc            = "CreateVdc"
+	OperationDeleteVdc            = "DeleteVdc"
+)
+
+// Step : A call planned to converge the live state with the desired state
+// Steps refer to resources by name. The IDs of resources that exist when the plan is made are filled in; the IDs of
+// resources created by earlier steps are resolved when the plan is applied.
+type Step struct {
+	// The VmwareV1 operation of the step, such as OperationSetHostsCount.
+	Operation string
+
+	// The name and, when it exists, the ID of the director site.
+	SiteName string
+	SiteID   string
+
+	// The name and ID of the cluster, for cluster steps and CreateVdc.
+	ClusterName string
+	ClusterID   string
+
+	// The name and ID of the Virtual Data Center, for VDC steps.
+	VDCName string
+	VDCID   string
+
+	// The desired site, for CreateWorkloadDomain.
+	Site *DesiredState
+
+	// The desired number of hosts, for SetHostsCount.
+	HostCount int64
+
+	// The file shares of every storage tier of the cluster, for SetFileShares: the desired sizes, and the live sizes
+	// of the tiers that the desired state does not list.
+	FileShares *vmwarev1.FileShares
+
+	// The desired Virtual Data Center, for CreateVdc.
+	VDC *DesiredVDC
+
+	// Why the step is needed.
+	Reason string
+}
+
+// String describes the step on a single line.
+func (step Step) String() string {
+	var target string
+	switch {
+	case step.VDCName != "":
+		target = fmt.Sprintf("VDC %s/%s", step.SiteName, step.VDCName)
+	case step.ClusterName != "":
+		target = fmt.Sprintf("cluster %s/%s", step.SiteName, step.ClusterName)
+	default:
+		target = fmt.Sprintf("director site %s", step.SiteName)
+	}
+	return fmt.Sprintf("%s %s: %s", step.Operation, target, step.Reason)
+}
+
+// Plan : The steps that converge the live state with a desired state
+type Plan struct {
+	// The desired state the plan was made for.
+	Desired *DesiredState
+
+	// The steps in the order they must be applied.
+	Steps []Step
+
+	// Differences that cannot be reconciled through the API, such as clusters missing from an existing director site
+	// or a changed host profile. They are reported but do not produce steps.
+	Warnings []string
+}
+
+// Empty reports whether the live state already matches the desired state.
+func (plan *Plan) Empty() bool {
+	return len(plan.Steps) == 0
+}
+
+// String describes the plan with one line per step and warning.
+func (plan *Plan) String() string {
+	var sb strings.Builder
+	if plan.Empty() {
+		sb.WriteString("No changes.\n")
+	}
+	for i, step := range plan.Steps {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, step.String())
+	}
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(&sb, "Warning: %s\n", warning)
+	}
+	return sb.String()
+}
+
+// Plan : Compute the steps that converge the live state with the desired state
+// The director site is looked up by name with ListWorkloadDomainInstances, ignoring deleted sites, and its Virtual
+// Data Centers are looked up with ListVdcs. Virtual Data Centers of the site that are not in the desired state are
+// deleted.
+func (reconciler *Reconciler) Plan(ctx context.Context, desired *DesiredState) (plan *Plan, err error) {
+	err = core.ValidateNotNil(desired, "desired cannot be nil")
+	if err != nil {
+		return
+	}
+	if err = desired.Validate(); err != nil {
+		return
+	}
+	plan = &Plan{Desired: desired}
+
+	site, err := reconciler.findSite(ctx, desired.Name)
+	if err != nil {
+		return nil, err
+	}
+	if site == nil {
+		plan.Steps = append(plan.Steps, Step{
+			Operation: OperationCreateWorkloadDomain,
+			SiteName:  desired.Name,
+			Site:      desired,
+			Reason:    fmt.Sprintf("create with %d cluster(s)", len(desired.Clusters)),
+		})
+		for i := range desired.VDCs {
+			plan.Steps = append(plan.Steps, createVdcStep(desired, "", nil, &desired.VDCs[i]))
+		}
+		return
+	}
+
+	siteID := core.StringNilMapper(site.ID)
+	liveClusters := make(map[string]*vmwarev1.ClusterSummary)
+	for i := range site.Clusters {
+		liveClusters[core.StringNilMapper(site.Clusters[i].Name)] = &site.Clusters[i]
+	}
+	if status := core.StringNilMapper(site.Status); status != vmwarev1.DirectorSite_Status_Readytouse {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("director site %s is %s; the plan may fail until it is %s", desired.Name, status, vmwarev1.DirectorSite_Status_Readytouse))
+	}
+
+	for i := range desired.Clusters {
+		cluster := &desired.Clusters[i]
+		live, ok := liveClusters[cluster.Name]
+		if !ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("cluster %s/%s does not exist and clusters cannot be added to an existing director site", desired.Name, cluster.Name))
+			continue
+		}
+		delete(liveClusters, cluster.Name)
+		clusterID := core.StringNilMapper(live.ID)
+
+		if cluster.Location != "" && cluster.Location != core.StringNilMapper(live.Location) {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("cluster %s/%s is in %s, not %s; the location cannot be changed", desired.Name, cluster.Name, core.StringNilMapper(live.Location), cluster.Location))
+		}
+		if cluster.HostProfile != "" && cluster.HostProfile != core.StringNilMapper(live.HostProfile) {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("cluster %s/%s uses %s, not %s; the host profile cannot be changed", desired.Name, cluster.Name, core.StringNilMapper(live.HostProfile), cluster.HostProfile))
+		}
+
+		liveHostCount := int64(0)
+		if live.HostCount != nil {
+			liveHostCount = *live.HostCount
+		}
+		if cluster.HostCount != liveHostCount {
+			plan.Steps = append(plan.Steps, Step{
+				Operation:   OperationSetHostsCount,
+				SiteName:    desired.Name,
+				SiteID:      siteID,
+				ClusterName: cluster.Name,
+				ClusterID:   clusterID,
+				HostCount:   cluster.HostCount,
+				Reason:      fmt.Sprintf("host count %d -> %d", liveHostCount, cluster.HostCount),
+			})
+		}
+
+		liveFileShares, err := live.FileShareSizes()
+		if err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("cluster %s/%s has file shares that cannot be compared: %s", desired.Name, cluster.Name, err.Error()))
+		} else if changes := fileSharesChanges(cluster.FileShares, liveFileShares); len(changes) > 0 {
+			// SetFileShares replaces every tier, so the live tiers that are not listed are sent as they are.
+			if unknown := liveFileShares.Unknown(); len(unknown) > 0 {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("cluster %s/%s has storage tiers %s that SetFileShares cannot keep; its file shares are left unchanged", desired.Name, cluster.Name, strings.Join(unknown.Tiers(), ", ")))
+			} else {
+				fileShares := make(vmwarev1.FileShareSizes, len(liveFileShares)+len(cluster.FileShares))
+				for tier, size := range liveFileShares {
+					fileShares[tier] = size
+				}
+				for tier, size := range cluster.FileShares {
+					fileShares[tier] = size
+				}
+				plan.Steps = append(plan.Steps, Step{
+					Operation:   OperationSetFileShares,
+					SiteName:    desired.Name,
+					SiteID:      siteID,
+					ClusterName: cluster.Name,
+					ClusterID:   clusterID,
+					FileShares:  fileShares.FileShares(),
+					Reason:      strings.Join(changes, ", "),
+				})
+			}
+		}
+	}
+	for name := range liveClusters {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("cluster %s/%s is not in the desired state and clusters cannot be removed from a director site", desired.Name, name))
+	}
+
+	vdcs, err := reconciler.listVdcs(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	liveVdcs := make(map[string]*vmwarev1.VDC)
+	for i := range vdcs {
+		liveVdcs[core.StringNilMapper(vdcs[i].Name)] = &vdcs[i]
+	}
+	desiredVdcs := make(map[string]bool)
+	for i := range desired.VDCs {
+		desiredVdcs[desired.VDCs[i].Name] = true
+	}
+	for i := range vdcs {
+		name := core.StringNilMapper(vdcs[i].Name)
+		if !desiredVdcs[name] {
+			plan.Steps = append(plan.Steps, Step{
+				Operation: OperationDeleteVdc,
+				SiteName:  desired.Name,
+				SiteID:    siteID,
+				VDCName:   name,
+				VDCID:     core.StringNilMapper(vdcs[i].ID),
+				Reason:    "not in the desired state",
+			})
+		}
+	}
+	for i := range desired.VDCs {
+		vdc := &desired.VDCs[i]
+		live, ok := liveVdcs[vdc.Name]
+		if !ok {
+			plan.Steps = append(plan.Steps, createVdcStep(desired, siteID, site, vdc))
+			continue
+		}
+		plan.Warnings = append(plan.Warnings, vdcDrift(desired.Name, site, vdc, live)...)
+	}
+	sort.Strings(plan.Warnings)
+	return
+}
+
+func createVdcStep(desired *DesiredState, siteID string, site *vmwarev1.DirectorSite, vdc *DesiredVDC) Step {
+	step := Step{
+		Operation:   OperationCreateVdc,
+		SiteName:    desired.Name,
+		SiteID:      siteID,
+		ClusterName: vdc.Cluster,
+		VDCName:     vdc.Name,
+		VDC:         vdc,
+		Reason:      fmt.Sprintf("create on cluster %s", vdc.Cluster),
+	}
+	if site != nil {
+		for _, cluster := range site.Clusters {
+			if core.StringNilMapper(cluster.Name) == vdc.Cluster {
+				step.ClusterID = core.StringNilMapper(cluster.ID)
+			}
+		}
+	}
+	return step
+}
+
+// vdcDrift reports the differences of an existing Virtual Data Center that cannot be changed.
+func vdcDrift(siteName string, site *vmwarev1.DirectorSite, desired *DesiredVDC, live *vmwarev1.VDC) (warnings []string) {
+	if live.DirectorSite != nil && live.DirectorSite.Cluster != nil {
+		liveClusterID := core.StringNilMapper(live.DirectorSite.Cluster.ID)
+		for _, cluster := range site.Clusters {
+			if core.StringNilMapper(cluster.ID) == liveClusterID && core.StringNilMapper(cluster.Name) != desired.Cluster {
+				warnings = append(warnings, fmt.Sprintf("VDC %s/%s is on cluster %s, not %s; delete it to move it", siteName, desired.Name, core.StringNilMapper(cluster.Name), desired.Cluster))
+			}
+		}
+	}
+	if desired.Edge != nil && len(live.Edges) > 0 {
+		edge := live.Edges[0]
+		if desired.Edge.Type != core.StringNilMapper(edge.Type) || (desired.Edge.Size != "" && desired.Edge.Size != core.StringNilMapper(edge.Size)) {
+			warnings = append(warnings, fmt.Sprintf("VDC %s/%s has a %s edge, not %s; delete it to change the edge", siteName, desired.Name, edgeName(edge.Type, edge.Size), edgeName(&desired.Edge.Type, &desired.Edge.Size)))
+		}
+	}
+	return
+}
+
+func edgeName(edgeType *string, size *string) string {
+	if core.StringNilMapper(size) == "" {
+		return core.StringNilMapper(edgeType)
+	}
+	return fmt.Sprintf("%s %s", core.StringNilMapper(size), core.StringNilMapper(edgeType))
+}
+
+// fileSharesChanges describes the storage tiers whose desired size differs from the live file shares.
+func fileSharesChanges(desired map[string]int64, live vmwarev1.FileShareSizes) (changes []string) {
+	tiers := make([]string, 0, len(desired))
+	for tier := range desired {
+		tiers = append(tiers, tier)
+	}
+	sort.Strings(tiers)
+	for _, tier := range tiers {
+		if desired[tier] != live[tier] {
+			changes = append(changes, fmt.Sprintf("%s %d -> %d", tier, live[tier], desired[tier]))
+		}
+	}
+	return
+}
+
+// findSite returns the live director site with the name, or nil when there is none.
+func (reconciler *Reconciler) findSite(ctx context.Context, name string) (*vmwarev1.DirectorSite, error) {
+	sites, _, err := reconciler.client.ListWorkloadDomainInstancesWithContext(ctx, &vmwarev1.ListWorkloadDomainInstancesOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var found *vmwarev1.DirectorSite
+	for i := range sites.DirectorSites {
+		site := &sites.DirectorSites[i]
+		if core.StringNilMapper(site.Name) != name || isSiteDeleted(site) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found several director sites named %s", name)
+		}
+		found = site
+	}
+	return found, nil
+}
+
+// listVdcs returns the live Virtual Data Centers of the director site.
+func (reconciler *Reconciler) listVdcs(ctx context.Context, siteID string) (vdcs []vmwarev1.VDC, err error) {
+	list, _, err := reconciler.client.ListVdcsWithContext(ctx, &vmwarev1.ListVdcsOptions{})
+	if err != nil {
+		return
+	}
+	for _, vdc := range list.Vdcs {
+		if vdc.DirectorSite == nil || core.StringNilMapper(vdc.DirectorSite.ID) != siteID || isVdcDeleted(&vdc) {
+			continue
+		}
+		vdcs = append(vdcs, vdc)
+	}
+	return
+}
+
+// isSiteDeleted reports whether the director site is going away.
+func isSiteDeleted(site *vmwarev1.DirectorSite) bool {
+	status := core.StringNilMapper(site.Status)
+	return status == vmwarev1.DirectorSite_Status_Deleting || status == vmwarev1.DirectorSite_Status_Deleted
+}
+
+// isVdcDeleted reports whether the Virtual Data Center is going away.
+func isVdcDeleted(vdc *vmwarev1.VDC) bool {
+	status := core.StringNilMapper(vdc.Status)
+	return status == vmwarev1.VDC_Status_Deleting || status == vmwarev1.VDC_Status_Deleted
+}
@@ -0,0 +1,218 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const document = `
+name: site1
+resource_group: Default
+clusters:
+  - name: cluster1
+    location: dal10
+    host_profile: BM_2S_20_CORES_192_GB
+    host_count: 2
+    file_shares:
+      STORAGE_TWO_IOPS_GB: 24000
+  - name: cluster2
+    location: dal12
+    host_profile: BM_2S_20_CORES_192_GB
+    host_count: 3
+    file_shares:
+      STORAGE_TWO_IOPS_GB: 24000
+vdcs:
+  - name: vdc1
+    cluster: cluster1
+    edge:
+      type: shared
+`
+
+func newReconciler(t *testing.T) (*vmwarev1fake.Server, *vmwarev1.VmwareV1, *Reconciler) {
+	server := vmwarev1fake.NewServer(nil)
+	t.Cleanup(server.Close)
+	service, err := server.NewClient()
+	require.Nil(t, err)
+	return server, service, NewReconciler(service, &ReconcilerOptions{
+		Wait: &vmwarev1.WaitOptions{PollInterval: time.Millisecond, Timeout: 5 * time.Second},
+	})
+}
+
+func operations(plan *Plan) []string {
+	steps := make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		steps[i] = step.Operation
+	}
+	return steps
+}
+
+func TestReconcileCreatesAndConverges(t *testing.T) {
+	server, service, reconciler := newReconciler(t)
+	ctx := context.Background()
+	desired, err := ParseDesiredState(strings.NewReader(document))
+	require.Nil(t, err)
+
+	plan, err := reconciler.Reconcile(ctx, desired, true)
+	require.Nil(t, err)
+	assert.Equal(t, []string{OperationCreateWorkloadDomain, OperationCreateVdc}, operations(plan))
+	assert.Equal(t, 0, server.RequestCount("CreateWorkloadDomain"))
+
+	var applied []string
+	reconciler.options.OnStep = func(step Step) {
+		applied = append(applied, step.String())
+	}
+	require.Nil(t, reconciler.Apply(ctx, plan))
+	assert.Equal(t, []string{
+		"CreateWorkloadDomain director site site1: create with 2 cluster(s)",
+		"CreateVdc VDC site1/vdc1: create on cluster cluster1",
+	}, applied)
+
+	plan, err = reconciler.Plan(ctx, desired)
+	require.Nil(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+	assert.Equal(t, "No changes.\n", plan.String())
+
+	// Grow a cluster, add storage, replace the VDC and drift an immutable field.
+	desired.Clusters[0].HostCount = 4
+	desired.Clusters[1].FileShares[vmwarev1.StorageTierFourIopsGB] = 1000
+	desired.Clusters[1].HostProfile = vmwarev1fake.HostProfile384GB
+	desired.VDCs = []DesiredVDC{{Name: "vdc2", Cluster: "cluster2"}}
+
+	plan, err = reconciler.Reconcile(ctx, desired, false)
+	require.Nil(t, err)
+	assert.Equal(t, []string{OperationSetHostsCount, OperationSetFileShares, OperationDeleteVdc, OperationCreateVdc}, operations(plan))
+	assert.Equal(t, "host count 2 -> 4", plan.Steps[0].Reason)
+	assert.Equal(t, "STORAGE_FOUR_IOPS_GB 0 -> 1000", plan.Steps[1].Reason)
+	require.Len(t, plan.Warnings, 1)
+	assert.Contains(t, plan.Warnings[0], "the host profile cannot be changed")
+
+	sites, _, err := service.ListWorkloadDomainInstances(service.NewListWorkloadDomainInstancesOptions())
+	require.Nil(t, err)
+	require.Len(t, sites.DirectorSites, 1)
+	assert.Equal(t, int64(4), *sites.DirectorSites[0].Clusters[0].HostCount)
+	assert.Equal(t, float64(1000), sites.DirectorSites[0].Clusters[1].FileShares[vmwarev1.StorageTierFourIopsGB])
+	vdcs, _, err := service.ListVdcs(service.NewListVdcsOptions())
+	require.Nil(t, err)
+	require.Len(t, vdcs.Vdcs, 1)
+	assert.Equal(t, "vdc2", *vdcs.Vdcs[0].Name)
+	assert.Equal(t, *sites.DirectorSites[0].Clusters[1].ID, *vdcs.Vdcs[0].DirectorSite.Cluster.ID)
+}
+
+func TestReconcileKeepsUnlistedFileShares(t *testing.T) {
+	_, service, reconciler := newReconciler(t)
+	ctx := context.Background()
+	desired, err := ParseDesiredState(strings.NewReader(document))
+	require.Nil(t, err)
+	_, err = reconciler.Reconcile(ctx, desired, false)
+	require.Nil(t, err)
+
+	// Add a tier to cluster2 outside of the desired state.
+	sites, _, err := service.ListWorkloadDomainInstances(service.NewListWorkloadDomainInstancesOptions())
+	require.Nil(t, err)
+	siteID, clusterID := *sites.DirectorSites[0].ID, *sites.DirectorSites[0].Clusters[1].ID
+	setOptions := service.NewSetFileSharesOptions(siteID, clusterID).SetFileShareSizes(vmwarev1.FileShareSizes{
+		vmwarev1.StorageTierTwoIopsGB: 24000,
+		vmwarev1.StorageTierTenIopsGB: 500,
+	})
+	_, _, err = service.SetFileShares(setOptions)
+	require.Nil(t, err)
+	_, err = service.WaitForCluster(ctx, siteID, clusterID, reconciler.options.Wait)
+	require.Nil(t, err)
+
+	desired.Clusters[1].FileShares[vmwarev1.StorageTierFourIopsGB] = 1000
+	plan, err := reconciler.Reconcile(ctx, desired, false)
+	require.Nil(t, err)
+	require.Equal(t, []string{OperationSetFileShares}, operations(plan))
+	assert.Equal(t, "STORAGE_FOUR_IOPS_GB 0 -> 1000", plan.Steps[0].Reason)
+	assert.Equal(t, int64(500), *plan.Steps[0].FileShares.STORAGETENIOPSGB)
+
+	cluster, _, err := service.GetSpecificClusterInstance(service.NewGetSpecificClusterInstanceOptions(siteID, clusterID))
+	require.Nil(t, err)
+	sizes, err := cluster.FileShareSizes()
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.FileShareSizes{
+		vmwarev1.StorageTierTwoIopsGB:  24000,
+		vmwarev1.StorageTierFourIopsGB: 1000,
+		vmwarev1.StorageTierTenIopsGB:  500,
+	}, sizes)
+}
+
+func TestApplyReportsTheFailedStep(t *testing.T) {
+	server, _, reconciler := newReconciler(t)
+	ctx := context.Background()
+	desired, err := ParseDesiredState(strings.NewReader(document))
+	require.Nil(t, err)
+	server.InjectFault("CreateVdc", vmwarev1fake.Fault{StatusCode: 409, Times: 1})
+
+	_, err = reconciler.Reconcile(ctx, desired, false)
+	var stepErr *StepError
+	require.True(t, errors.As(err, &stepErr))
+	assert.Equal(t, 1, stepErr.Index)
+	assert.Equal(t, OperationCreateVdc, stepErr.Step.Operation)
+	assert.True(t, vmwarev1.IsConflict(err))
+
+	// The director site was created, so the next plan only retries the VDC.
+	plan, err := reconciler.Plan(ctx, desired)
+	require.Nil(t, err)
+	assert.Equal(t, []string{OperationCreateVdc}, operations(plan))
+	assert.NotEmpty(t, plan.Steps[0].ClusterID)
+}
+
+func TestParseDesiredState(t *testing.T) {
+	_, err := ParseDesiredState(strings.NewReader(`{"name": "site1", "resource_group": "Default", "clusters": [{"name": "c1", "host_count": 2, "file_shares": {"STORAGE_TWO_IOPS_GB": 1}}]}`))
+	assert.Nil(t, err)
+
+	_, err = ParseDesiredState(strings.NewReader(`
+name: site1
+clusters:
+  - name: c1
+    host_count: 0
+    file_shares:
+      STORAGE_UNKNOWN: 1
+  - name: c1
+    host_count: 2
+vdcs:
+  - name: vdc1
+    cluster: c2
+    edge:
+      type: huge
+`))
+	require.NotNil(t, err)
+	for _, problem := range []string{
+		"resource_group is required",
+		"clusters[0].host_count must be at least 1",
+		"clusters[0].file_shares",
+		`clusters[1].name "c1" is not unique`,
+		`vdcs[0].cluster "c2" is not a cluster of the director site`,
+		"vdcs[0].edge.type must be shared or dedicated",
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+
+	_, err = ParseDesiredState(strings.NewReader("name: site1\nunknown: true\n"))
+	assert.NotNil(t, err)
+}
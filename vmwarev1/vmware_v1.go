@@ -39,6 +39,16 @@ import (
 // API Version: 1.0
 type VmwareV1 struct {
 	Service *core.BaseService
+
+	// Optional behavior applied to every operation, see vmware_v1_request.go.
+	tracer         Tracer
+	metrics        MetricsCollector
+	transactionIDs *TransactionIDOptions
+	logging        *LoggingOptions
+	cache          *responseCache
+	budget         *BudgetOptions
+	dryRun         bool
+	audit          *AuditOptions
 }
 
 // DefaultServiceURL is the default URL to make service requests to.
@@ -226,7 +236,7 @@ func (vmware *VmwareV1) CreateWorkloadDomainWithContext(ctx context.Context, cre
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("CreateWorkloadDomain", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -284,7 +294,7 @@ func (vmware *VmwareV1) ListWorkloadDomainInstancesWithContext(ctx context.Conte
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("ListWorkloadDomainInstances", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -350,7 +360,7 @@ func (vmware *VmwareV1) GetSpecificWorkloadDomainInstanceWithContext(ctx context
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("GetSpecificWorkloadDomainInstance", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -416,7 +426,7 @@ func (vmware *VmwareV1) DeleteWorkloadDomainWithContext(ctx context.Context, del
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("DeleteWorkloadDomain", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -482,7 +492,7 @@ func (vmware *VmwareV1) ListClusterInstancesWithContext(ctx context.Context, lis
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("ListClusterInstances", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -549,7 +559,7 @@ func (vmware *VmwareV1) GetSpecificClusterInstanceWithContext(ctx context.Contex
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("GetSpecificClusterInstance", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -627,7 +637,7 @@ func (vmware *VmwareV1) SetHostsCountWithContext(ctx context.Context, setHostsCo
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("SetHostsCount", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -713,7 +723,7 @@ func (vmware *VmwareV1) SetFileSharesWithContext(ctx context.Context, setFileSha
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("SetFileShares", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -771,7 +781,7 @@ func (vmware *VmwareV1) GetRegionsWithContext(ctx context.Context, getRegionsOpt
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("GetRegions", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -831,7 +841,7 @@ func (vmware *VmwareV1) ViewInstanceWithContext(ctx context.Context, viewInstanc
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("ViewInstance", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -884,6 +894,9 @@ func (vmware *VmwareV1) ReplaceOrgAdminPasswordWithContext(ctx context.Context,
 		builder.AddHeader(headerName, headerValue)
 	}
 	builder.AddHeader("Accept", "application/json")
+	if replaceOrgAdminPasswordOptions.XGlobalTransactionID != nil {
+		builder.AddHeader("X-Global-Transaction-ID", fmt.Sprint(*replaceOrgAdminPasswordOptions.XGlobalTransactionID))
+	}
 
 	builder.AddQuery("site_id", fmt.Sprint(*replaceOrgAdminPasswordOptions.SiteID))
 
@@ -893,7 +906,7 @@ func (vmware *VmwareV1) ReplaceOrgAdminPasswordWithContext(ctx context.Context,
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("ReplaceOrgAdminPassword", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -951,7 +964,7 @@ func (vmware *VmwareV1) ListPricesWithContext(ctx context.Context, listPricesOpt
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("ListPrices", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1026,7 +1039,7 @@ func (vmware *VmwareV1) GetVcddPriceWithContext(ctx context.Context, getVcddPric
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("GetVcddPrice", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1074,6 +1087,9 @@ func (vmware *VmwareV1) ListVdcsWithContext(ctx context.Context, listVdcsOptions
 	if listVdcsOptions.AcceptLanguage != nil {
 		builder.AddHeader("Accept-Language", fmt.Sprint(*listVdcsOptions.AcceptLanguage))
 	}
+	if listVdcsOptions.XGlobalTransactionID != nil {
+		builder.AddHeader("X-Global-Transaction-ID", fmt.Sprint(*listVdcsOptions.XGlobalTransactionID))
+	}
 
 	request, err := builder.Build()
 	if err != nil {
@@ -1081,7 +1097,7 @@ func (vmware *VmwareV1) ListVdcsWithContext(ctx context.Context, listVdcsOptions
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("ListVdcs", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1134,6 +1150,9 @@ func (vmware *VmwareV1) CreateVdcWithContext(ctx context.Context, createVdcOptio
 	if createVdcOptions.AcceptLanguage != nil {
 		builder.AddHeader("Accept-Language", fmt.Sprint(*createVdcOptions.AcceptLanguage))
 	}
+	if createVdcOptions.XGlobalTransactionID != nil {
+		builder.AddHeader("X-Global-Transaction-ID", fmt.Sprint(*createVdcOptions.XGlobalTransactionID))
+	}
 
 	body := make(map[string]interface{})
 	if createVdcOptions.Name != nil {
@@ -1159,7 +1178,7 @@ func (vmware *VmwareV1) CreateVdcWithContext(ctx context.Context, createVdcOptio
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("CreateVdc", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1215,6 +1234,9 @@ func (vmware *VmwareV1) GetVdcWithContext(ctx context.Context, getVdcOptions *Ge
 	if getVdcOptions.AcceptLanguage != nil {
 		builder.AddHeader("Accept-Language", fmt.Sprint(*getVdcOptions.AcceptLanguage))
 	}
+	if getVdcOptions.XGlobalTransactionID != nil {
+		builder.AddHeader("X-Global-Transaction-ID", fmt.Sprint(*getVdcOptions.XGlobalTransactionID))
+	}
 
 	request, err := builder.Build()
 	if err != nil {
@@ -1222,7 +1244,7 @@ func (vmware *VmwareV1) GetVdcWithContext(ctx context.Context, getVdcOptions *Ge
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("GetVdc", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1278,6 +1300,9 @@ func (vmware *VmwareV1) DeleteVdcWithContext(ctx context.Context, deleteVdcOptio
 	if deleteVdcOptions.AcceptLanguage != nil {
 		builder.AddHeader("Accept-Language", fmt.Sprint(*deleteVdcOptions.AcceptLanguage))
 	}
+	if deleteVdcOptions.XGlobalTransactionID != nil {
+		builder.AddHeader("X-Global-Transaction-ID", fmt.Sprint(*deleteVdcOptions.XGlobalTransactionID))
+	}
 
 	request, err := builder.Build()
 	if err != nil {
@@ -1285,7 +1310,7 @@ func (vmware *VmwareV1) DeleteVdcWithContext(ctx context.Context, deleteVdcOptio
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = vmware.Service.Request(request, &rawResponse)
+	response, err = vmware.request("DeleteVdc", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1557,6 +1582,9 @@ type CreateVdcOptions struct {
 	// Language.
 	AcceptLanguage *string `json:"Accept-Language,omitempty"`
 
+	// Transaction id.
+	XGlobalTransactionID *string `json:"X-Global-Transaction-ID,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
@@ -1599,6 +1627,12 @@ func (_options *CreateVdcOptions) SetAcceptLanguage(acceptLanguage string) *Crea
 	return _options
 }
 
+// SetXGlobalTransactionID : Allow user to set XGlobalTransactionID
+func (_options *CreateVdcOptions) SetXGlobalTransactionID(xGlobalTransactionID string) *CreateVdcOptions {
+	_options.XGlobalTransactionID = core.StringPtr(xGlobalTransactionID)
+	return _options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *CreateVdcOptions) SetHeaders(param map[string]string) *CreateVdcOptions {
 	options.Headers = param
@@ -1711,6 +1745,9 @@ type DeleteVdcOptions struct {
 	// Language.
 	AcceptLanguage *string `json:"Accept-Language,omitempty"`
 
+	// Transaction id.
+	XGlobalTransactionID *string `json:"X-Global-Transaction-ID,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
@@ -1734,6 +1771,12 @@ func (_options *DeleteVdcOptions) SetAcceptLanguage(acceptLanguage string) *Dele
 	return _options
 }
 
+// SetXGlobalTransactionID : Allow user to set XGlobalTransactionID
+func (_options *DeleteVdcOptions) SetXGlobalTransactionID(xGlobalTransactionID string) *DeleteVdcOptions {
+	_options.XGlobalTransactionID = core.StringPtr(xGlobalTransactionID)
+	return _options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *DeleteVdcOptions) SetHeaders(param map[string]string) *DeleteVdcOptions {
 	options.Headers = param
@@ -2406,6 +2449,9 @@ type GetVdcOptions struct {
 	// Language.
 	AcceptLanguage *string `json:"Accept-Language,omitempty"`
 
+	// Transaction id.
+	XGlobalTransactionID *string `json:"X-Global-Transaction-ID,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
@@ -2429,6 +2475,12 @@ func (_options *GetVdcOptions) SetAcceptLanguage(acceptLanguage string) *GetVdcO
 	return _options
 }
 
+// SetXGlobalTransactionID : Allow user to set XGlobalTransactionID
+func (_options *GetVdcOptions) SetXGlobalTransactionID(xGlobalTransactionID string) *GetVdcOptions {
+	_options.XGlobalTransactionID = core.StringPtr(xGlobalTransactionID)
+	return _options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *GetVdcOptions) SetHeaders(param map[string]string) *GetVdcOptions {
 	options.Headers = param
@@ -2665,6 +2717,9 @@ type ListVdcsOptions struct {
 	// Language.
 	AcceptLanguage *string `json:"Accept-Language,omitempty"`
 
+	// Transaction id.
+	XGlobalTransactionID *string `json:"X-Global-Transaction-ID,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
@@ -2680,6 +2735,12 @@ func (_options *ListVdcsOptions) SetAcceptLanguage(acceptLanguage string) *ListV
 	return _options
 }
 
+// SetXGlobalTransactionID : Allow user to set XGlobalTransactionID
+func (_options *ListVdcsOptions) SetXGlobalTransactionID(xGlobalTransactionID string) *ListVdcsOptions {
+	_options.XGlobalTransactionID = core.StringPtr(xGlobalTransactionID)
+	return _options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *ListVdcsOptions) SetHeaders(param map[string]string) *ListVdcsOptions {
 	options.Headers = param
@@ -3023,6 +3084,9 @@ type ReplaceOrgAdminPasswordOptions struct {
 	// A unique identifier for the director site.
 	SiteID *string `json:"site_id" validate:"required"`
 
+	// Transaction id.
+	XGlobalTransactionID *string `json:"X-Global-Transaction-ID,omitempty"`
+
 	// Allows users to set headers on API requests
 	Headers map[string]string
 }
@@ -3040,6 +3104,12 @@ func (_options *ReplaceOrgAdminPasswordOptions) SetSiteID(siteID string) *Replac
 	return _options
 }
 
+// SetXGlobalTransactionID : Allow user to set XGlobalTransactionID
+func (_options *ReplaceOrgAdminPasswordOptions) SetXGlobalTransactionID(xGlobalTransactionID string) *ReplaceOrgAdminPasswordOptions {
+	_options.XGlobalTransactionID = core.StringPtr(xGlobalTransactionID)
+	return _options
+}
+
 // SetHeaders : Allow user to set Headers
 func (options *ReplaceOrgAdminPasswordOptions) SetHeaders(param map[string]string) *ReplaceOrgAdminPasswordOptions {
 	options.Headers = param
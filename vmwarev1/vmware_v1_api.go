@@ -0,0 +1,99 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// VmwareV1API : The operations of the VmwareV1 service
+// VmwareV1API is implemented by *VmwareV1. Code that depends on this interface instead of the concrete client can be
+// exercised in unit tests with a fake implementation.
+type VmwareV1API interface {
+	// CreateWorkloadDomain : Create a director site instance
+	CreateWorkloadDomain(createWorkloadDomainOptions *CreateWorkloadDomainOptions) (result *DirectorSite, response *core.DetailedResponse, err error)
+	CreateWorkloadDomainWithContext(ctx context.Context, createWorkloadDomainOptions *CreateWorkloadDomainOptions) (result *DirectorSite, response *core.DetailedResponse, err error)
+
+	// ListWorkloadDomainInstances : List director site instances
+	ListWorkloadDomainInstances(listWorkloadDomainInstancesOptions *ListWorkloadDomainInstancesOptions) (result *ListDirectorSites, response *core.DetailedResponse, err error)
+	ListWorkloadDomainInstancesWithContext(ctx context.Context, listWorkloadDomainInstancesOptions *ListWorkloadDomainInstancesOptions) (result *ListDirectorSites, response *core.DetailedResponse, err error)
+
+	// GetSpecificWorkloadDomainInstance : Get a director site instance
+	GetSpecificWorkloadDomainInstance(getSpecificWorkloadDomainInstanceOptions *GetSpecificWorkloadDomainInstanceOptions) (result *DirectorSite, response *core.DetailedResponse, err error)
+	GetSpecificWorkloadDomainInstanceWithContext(ctx context.Context, getSpecificWorkloadDomainInstanceOptions *GetSpecificWorkloadDomainInstanceOptions) (result *DirectorSite, response *core.DetailedResponse, err error)
+
+	// DeleteWorkloadDomain : Delete a director site instance
+	DeleteWorkloadDomain(deleteWorkloadDomainOptions *DeleteWorkloadDomainOptions) (result *DirectorSite, response *core.DetailedResponse, err error)
+	DeleteWorkloadDomainWithContext(ctx context.Context, deleteWorkloadDomainOptions *DeleteWorkloadDomainOptions) (result *DirectorSite, response *core.DetailedResponse, err error)
+
+	// ListClusterInstances : List clusters
+	ListClusterInstances(listClusterInstancesOptions *ListClusterInstancesOptions) (result *ListClusters, response *core.DetailedResponse, err error)
+	ListClusterInstancesWithContext(ctx context.Context, listClusterInstancesOptions *ListClusterInstancesOptions) (result *ListClusters, response *core.DetailedResponse, err error)
+
+	// GetSpecificClusterInstance : Get a cluster
+	GetSpecificClusterInstance(getSpecificClusterInstanceOptions *GetSpecificClusterInstanceOptions) (result *Cluster, response *core.DetailedResponse, err error)
+	GetSpecificClusterInstanceWithContext(ctx context.Context, getSpecificClusterInstanceOptions *GetSpecificClusterInstanceOptions) (result *Cluster, response *core.DetailedResponse, err error)
+
+	// SetHostsCount : Update the number of hosts of a cluster
+	SetHostsCount(setHostsCountOptions *SetHostsCountOptions) (result *SetHostsCountResponse, response *core.DetailedResponse, err error)
+	SetHostsCountWithContext(ctx context.Context, setHostsCountOptions *SetHostsCountOptions) (result *SetHostsCountResponse, response *core.DetailedResponse, err error)
+
+	// SetFileShares : Update the file storage shares of a cluster
+	SetFileShares(setFileSharesOptions *SetFileSharesOptions) (result *FileShares, response *core.DetailedResponse, err error)
+	SetFileSharesWithContext(ctx context.Context, setFileSharesOptions *SetFileSharesOptions) (result *FileShares, response *core.DetailedResponse, err error)
+
+	// GetRegions : List regions
+	GetRegions(getRegionsOptions *GetRegionsOptions) (result *DirectorSiteRegions, response *core.DetailedResponse, err error)
+	GetRegionsWithContext(ctx context.Context, getRegionsOptions *GetRegionsOptions) (result *DirectorSiteRegions, response *core.DetailedResponse, err error)
+
+	// ViewInstance : List host profiles
+	ViewInstance(viewInstanceOptions *ViewInstanceOptions) (result *ListHostProfiles, response *core.DetailedResponse, err error)
+	ViewInstanceWithContext(ctx context.Context, viewInstanceOptions *ViewInstanceOptions) (result *ListHostProfiles, response *core.DetailedResponse, err error)
+
+	// ReplaceOrgAdminPassword : Replace the password of VMware Cloud Director tenant portal
+	ReplaceOrgAdminPassword(replaceOrgAdminPasswordOptions *ReplaceOrgAdminPasswordOptions) (result *NewPassword, response *core.DetailedResponse, err error)
+	ReplaceOrgAdminPasswordWithContext(ctx context.Context, replaceOrgAdminPasswordOptions *ReplaceOrgAdminPasswordOptions) (result *NewPassword, response *core.DetailedResponse, err error)
+
+	// ListPrices : List billing metrics
+	ListPrices(listPricesOptions *ListPricesOptions) (result *DirectorSitePricingInfo, response *core.DetailedResponse, err error)
+	ListPricesWithContext(ctx context.Context, listPricesOptions *ListPricesOptions) (result *DirectorSitePricingInfo, response *core.DetailedResponse, err error)
+
+	// GetVcddPrice : Quote price
+	GetVcddPrice(getVcddPriceOptions *GetVcddPriceOptions) (result *DirectorSitePriceQuoteResponse, response *core.DetailedResponse, err error)
+	GetVcddPriceWithContext(ctx context.Context, getVcddPriceOptions *GetVcddPriceOptions) (result *DirectorSitePriceQuoteResponse, response *core.DetailedResponse, err error)
+
+	// ListVdcs : List Virtual Data Centers
+	ListVdcs(listVdcsOptions *ListVdcsOptions) (result *ListVDCs, response *core.DetailedResponse, err error)
+	ListVdcsWithContext(ctx context.Context, listVdcsOptions *ListVdcsOptions) (result *ListVDCs, response *core.DetailedResponse, err error)
+
+	// CreateVdc : Create a Virtual Data Center
+	CreateVdc(createVdcOptions *CreateVdcOptions) (result *VDC, response *core.DetailedResponse, err error)
+	CreateVdcWithContext(ctx context.Context, createVdcOptions *CreateVdcOptions) (result *VDC, response *core.DetailedResponse, err error)
+
+	// GetVdc : Get a Virtual Data Center
+	GetVdc(getVdcOptions *GetVdcOptions) (result *VDC, response *core.DetailedResponse, err error)
+	GetVdcWithContext(ctx context.Context, getVdcOptions *GetVdcOptions) (result *VDC, response *core.DetailedResponse, err error)
+
+	// DeleteVdc : Delete a Virtual Data Center
+	DeleteVdc(deleteVdcOptions *DeleteVdcOptions) (result *VDC, response *core.DetailedResponse, err error)
+	DeleteVdcWithContext(ctx context.Context, deleteVdcOptions *DeleteVdcOptions) (result *VDC, response *core.DetailedResponse, err error)
+}
+
+// Verify that VmwareV1 implements VmwareV1API.
+var _ VmwareV1API = (*VmwareV1)(nil)
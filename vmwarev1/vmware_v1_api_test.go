@@ -0,0 +1,62 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeVmwareV1 overrides ListVdcsWithContext and panics on any other operation.
+type fakeVmwareV1 struct {
+	vmwarev1.VmwareV1API
+	vdcs []vmwarev1.VDC
+}
+
+func (fake *fakeVmwareV1) ListVdcsWithContext(ctx context.Context, listVdcsOptions *vmwarev1.ListVdcsOptions) (*vmwarev1.ListVDCs, *core.DetailedResponse, error) {
+	return &vmwarev1.ListVDCs{Vdcs: fake.vdcs}, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+// countVdcs stands in for consumer code that depends on the interface instead of *VmwareV1.
+func countVdcs(api vmwarev1.VmwareV1API) (int, error) {
+	result, _, err := api.ListVdcsWithContext(context.Background(), &vmwarev1.ListVdcsOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Vdcs), nil
+}
+
+var _ = Describe(`VmwareV1API`, func() {
+	It(`Accept the service client`, func() {
+		vmwareService, serviceErr := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+
+		var api vmwarev1.VmwareV1API = vmwareService
+		Expect(api).ToNot(BeNil())
+	})
+	It(`Accept a fake implementation`, func() {
+		count, err := countVdcs(&fakeVmwareV1{vdcs: make([]vmwarev1.VDC, 3)})
+		Expect(err).To(BeNil())
+		Expect(count).To(Equal(3))
+	})
+})
@@ -0,0 +1,201 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// AuditRecord : The record of a call that may change resources
+type AuditRecord struct {
+	// When the call completed, in UTC.
+	Time time.Time `json:"time"`
+
+	// The operationId and HTTP method of the call, such as "SetHostsCount" and "PUT".
+	Operation string `json:"operation"`
+	Method    string `json:"method"`
+
+	// The site_id, cluster_id and vdc_id parameters of the call.
+	Params map[string]string `json:"params,omitempty"`
+
+	// The JSON body of the request. The fields of RedactedFields are redacted, and bodies that are not JSON are
+	// replaced by Redacted.
+	RequestBody json.RawMessage `json:"request_body,omitempty"`
+
+	// The HTTP status code of the response, or zero when no response was received, and the error of the call.
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+
+	// The X-Global-Transaction-ID of the call, when it has one.
+	TransactionID string `json:"transaction_id,omitempty"`
+
+	// Who made the call: the IAM ID, or the subject, of the bearer token sent with the call, or the user name of basic
+	// authentication. Empty when the authenticator sends neither.
+	Identity string `json:"identity,omitempty"`
+
+	// The type of the authenticator of the client, such as core.AUTHTYPE_IAM.
+	AuthenticationType string `json:"authentication_type,omitempty"`
+}
+
+// AuditSink : A destination of audit records
+// Audit is called once per audited call, after the call completes, and may be called concurrently.
+type AuditSink interface {
+	Audit(ctx context.Context, record AuditRecord) error
+}
+
+// AuditOptions : Options for the audit trail of VmwareV1
+type AuditOptions struct {
+	// The sink that receives the audit records. Required.
+	Sink AuditSink
+
+	// Invoked when the sink fails to take a record. The call itself is not affected. Defaults to dropping the error.
+	OnError func(record AuditRecord, err error)
+
+	// The clock used to timestamp records. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// EnableAudit : Record every call that is not a GET
+// A record is sent to options.Sink when each call other than a GET completes, whether it succeeds or fails. Calls
+// that are not sent, such as those planned in dry-run mode or rejected by the budget guard, are not recorded.
+func (vmware *VmwareV1) EnableAudit(options *AuditOptions) error {
+	err := core.ValidateNotNil(options, "options cannot be nil")
+	if err != nil {
+		return err
+	}
+	err = core.ValidateNotNil(options.Sink, "options.Sink cannot be nil")
+	if err != nil {
+		return err
+	}
+	resolved := *options
+	if resolved.Now == nil {
+		resolved.Now = time.Now
+	}
+	vmware.audit = &resolved
+	return nil
+}
+
+// DisableAudit : Stop recording calls
+func (vmware *VmwareV1) DisableAudit() {
+	vmware.audit = nil
+}
+
+// auditEntry : The audit record of a call in progress
+type auditEntry struct {
+	options *AuditOptions
+	record  AuditRecord
+}
+
+// startAudit begins the audit record of a call, or returns nil when the call is not audited. The request body is
+// read before the request is sent.
+func (vmware *VmwareV1) startAudit(operation string, request *http.Request) *auditEntry {
+	if vmware.audit == nil || request.Method == http.MethodGet {
+		return nil
+	}
+	entry := &auditEntry{
+		options: vmware.audit,
+		record: AuditRecord{
+			Operation: operation,
+			Method:    request.Method,
+			Params:    resourceParams(operation, request),
+		},
+	}
+	if len(entry.record.Params) == 0 {
+		entry.record.Params = nil
+	}
+	if body, err := requestBody(request); err != nil {
+		entry.record.RequestBody = json.RawMessage(`"` + Redacted + `"`)
+	} else if len(body) > 0 {
+		redacted := redactBody(body)
+		if !json.Valid([]byte(redacted)) {
+			redacted = `"` + redacted + `"`
+		}
+		entry.record.RequestBody = json.RawMessage(redacted)
+	}
+	if authenticator := vmware.Service.Options.Authenticator; !core.IsNil(authenticator) {
+		entry.record.AuthenticationType = authenticator.AuthenticationType()
+	}
+	return entry
+}
+
+// end completes the audit record once the call completes and sends it to the sink. The request carries the
+// authentication header added when it was sent.
+func (entry *auditEntry) end(request *http.Request, response *core.DetailedResponse, transactionID string, err error) {
+	record := entry.record
+	record.Time = entry.options.Now().UTC()
+	record.TransactionID = transactionID
+	record.Identity = requestIdentity(request)
+	if response != nil {
+		record.StatusCode = response.StatusCode
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if sinkErr := entry.options.Sink.Audit(request.Context(), record); sinkErr != nil && entry.options.OnError != nil {
+		entry.options.OnError(record, sinkErr)
+	}
+}
+
+// requestIdentity returns the identity in the Authorization header of the request: the iam_id or sub claim of a
+// bearer token, or the user name of basic authentication. The token is not verified; it was accepted or rejected by
+// the service.
+func requestIdentity(request *http.Request) string {
+	authorization := ""
+	for name, values := range request.Header {
+		if strings.EqualFold(name, "Authorization") && len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	scheme, credentials, _ := strings.Cut(authorization, " ")
+	switch {
+	case strings.EqualFold(scheme, "Bearer"):
+		parts := strings.Split(credentials, ".")
+		if len(parts) != 3 {
+			return ""
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+		if err != nil {
+			return ""
+		}
+		var claims struct {
+			IAMID   string `json:"iam_id"`
+			Subject string `json:"sub"`
+		}
+		if json.Unmarshal(payload, &claims) != nil {
+			return ""
+		}
+		if claims.IAMID != "" {
+			return claims.IAMID
+		}
+		return claims.Subject
+	case strings.EqualFold(scheme, "Basic"):
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return ""
+		}
+		user, _, _ := strings.Cut(string(decoded), ":")
+		return user
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1audit"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// failingSink is an audit sink that rejects every record.
+type failingSink struct{}
+
+func (failingSink) Audit(ctx context.Context, record vmwarev1.AuditRecord) error {
+	return errors.New("disk full")
+}
+
+var _ = Describe(`VmwareV1 audit trail`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+	var sink *vmwarev1audit.MemorySink
+	now := time.Date(2022, 11, 15, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		// An unsigned token with the claims of an IAM access token.
+		claims := base64.RawURLEncoding.EncodeToString([]byte(`{"iam_id":"IBMid-123","sub":"someone@example.com"}`))
+		authenticator, err := core.NewBearerTokenAuthenticator("e30." + claims + ".c2ln")
+		Expect(err).To(BeNil())
+		vmwareService, err = vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{URL: server.URL, Authenticator: authenticator})
+		Expect(err).To(BeNil())
+		sink = vmwarev1audit.NewMemorySink()
+		Expect(vmwareService.EnableAudit(&vmwarev1.AuditOptions{
+			Sink: sink,
+			Now:  func() time.Time { return now },
+		})).To(Succeed())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	createSite := func() *vmwarev1.DirectorSite {
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		site, _, err := vmwareService.CreateWorkloadDomain(vmwareService.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+		Expect(err).To(BeNil())
+		server.CompletePending()
+		return site
+	}
+
+	It(`Record every call that is not a GET`, func() {
+		site := createSite()
+		_, _, err := vmwareService.GetSpecificWorkloadDomainInstance(vmwareService.NewGetSpecificWorkloadDomainInstanceOptions(*site.ID))
+		Expect(err).To(BeNil())
+		_, _, err = vmwareService.SetHostsCountWithContext(vmwarev1.WithTransactionID(context.Background(), "testTransaction"),
+			vmwareService.NewSetHostsCountOptions(*site.ID, *site.Clusters[0].ID, 3))
+		Expect(err).To(BeNil())
+		_, _, err = vmwareService.ReplaceOrgAdminPassword(vmwareService.NewReplaceOrgAdminPasswordOptions(*site.ID))
+		Expect(err).To(BeNil())
+
+		records := sink.Records()
+		Expect(records).To(HaveLen(3))
+		create := records[0]
+		Expect(create.Time).To(Equal(now.UTC()))
+		Expect(create.Operation).To(Equal("CreateWorkloadDomain"))
+		Expect(create.Method).To(Equal("POST"))
+		Expect(create.Params).To(BeNil())
+		Expect(create.StatusCode).To(Equal(202))
+		Expect(create.Error).To(BeEmpty())
+		Expect(create.Identity).To(Equal("IBMid-123"))
+		Expect(create.AuthenticationType).To(Equal(core.AUTHTYPE_BEARER_TOKEN))
+		var body map[string]interface{}
+		Expect(json.Unmarshal(create.RequestBody, &body)).To(Succeed())
+		Expect(body["name"]).To(Equal("site1"))
+
+		setHostsCount := records[1]
+		Expect(setHostsCount.Operation).To(Equal("SetHostsCount"))
+		Expect(setHostsCount.Params).To(Equal(map[string]string{"site_id": *site.ID, "cluster_id": *site.Clusters[0].ID}))
+		Expect(string(setHostsCount.RequestBody)).To(Equal(`{"count":3}`))
+		Expect(setHostsCount.TransactionID).To(Equal("testTransaction"))
+
+		replacePassword := records[2]
+		Expect(replacePassword.Operation).To(Equal("ReplaceOrgAdminPassword"))
+		Expect(replacePassword.Params).To(Equal(map[string]string{"site_id": *site.ID}))
+		Expect(replacePassword.RequestBody).To(BeNil())
+	})
+	It(`Record failed calls but not calls that are not sent`, func() {
+		server.InjectFault("DeleteVdc", vmwarev1fake.Fault{StatusCode: 409, Times: 1})
+		_, _, err := vmwareService.DeleteVdc(vmwareService.NewDeleteVdcOptions("testVdc"))
+		Expect(err).ToNot(BeNil())
+		_, _, err = vmwareService.DeleteVdcWithContext(vmwarev1.WithDryRun(context.Background()), vmwareService.NewDeleteVdcOptions("testVdc"))
+		var dryRunErr *vmwarev1.DryRunError
+		Expect(errors.As(err, &dryRunErr)).To(BeTrue())
+
+		records := sink.Records()
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Operation).To(Equal("DeleteVdc"))
+		Expect(records[0].Params).To(Equal(map[string]string{"vdc_id": "testVdc"}))
+		Expect(records[0].StatusCode).To(Equal(409))
+		Expect(records[0].Error).ToNot(BeEmpty())
+	})
+	It(`Report the errors of the sink without failing the call`, func() {
+		var sinkErrors []error
+		Expect(vmwareService.EnableAudit(&vmwarev1.AuditOptions{
+			Sink: failingSink{},
+			OnError: func(record vmwarev1.AuditRecord, err error) {
+				Expect(record.Operation).To(Equal("CreateWorkloadDomain"))
+				sinkErrors = append(sinkErrors, err)
+			},
+		})).To(Succeed())
+		createSite()
+		Expect(sinkErrors).To(HaveLen(1))
+
+		vmwareService.DisableAudit()
+		createSite()
+		Expect(sinkErrors).To(HaveLen(1))
+	})
+	It(`Invoke EnableAudit with error: no sink`, func() {
+		Expect(vmwareService.EnableAudit(nil)).ToNot(Succeed())
+		Expect(vmwareService.EnableAudit(&vmwarev1.AuditOptions{})).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,330 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Scopes of a budget, the values of BudgetExceededError.Scope.
+const (
+	BudgetScopeSite    = "site"
+	BudgetScopeAccount = "account"
+)
+
+// BudgetedOperations lists the operations checked by the budget guard.
+var BudgetedOperations = []string{"CreateWorkloadDomain", "SetHostsCount"}
+
+// BudgetOptions : Options for the budget guard
+type BudgetOptions struct {
+	// The largest monthly price of a single director site. Zero does not limit director sites.
+	SiteLimit float64
+
+	// The largest monthly price of all the director sites of the account together. Zero does not limit the account.
+	AccountLimit float64
+
+	// The currency of the limits, such as "USD". A price quoted in another currency fails the call. Empty accepts
+	// the currency of the quotes.
+	Currency string
+
+	// The billing country passed to GetVcddPrice. Empty leaves the billing country to the service.
+	Country string
+
+	// Invoked when a call that exceeds a budget goes ahead because its context carries an override.
+	OnOverride func(ctx context.Context, exceeded *BudgetExceededError, reason string)
+}
+
+// BudgetExceededError is returned by CreateWorkloadDomain and SetHostsCount when the budget guard is enabled and the
+// call would raise a monthly price over its limit. The call is not sent to the service.
+type BudgetExceededError struct {
+	// The operation that was rejected.
+	Operation string
+
+	// The director site whose price exceeds the limit, or the director site being ordered or resized when the
+	// account limit is exceeded. SiteID is empty for CreateWorkloadDomain.
+	SiteID   string
+	SiteName string
+
+	// The budget that would be exceeded, BudgetScopeSite or BudgetScopeAccount.
+	Scope string
+
+	// The monthly price after the call and the limit of the budget, in Currency.
+	Price    float64
+	Limit    float64
+	Currency string
+}
+
+// Error returns the error message.
+func (e *BudgetExceededError) Error() string {
+	site := e.SiteName
+	if site == "" {
+		site = e.SiteID
+	}
+	return fmt.Sprintf("%s of director site %s would raise the monthly %s price to %.2f %s, over the budget of %.2f %s",
+		e.Operation, site, e.Scope, e.Price, e.Currency, e.Limit, e.Currency)
+}
+
+// budgetOverrideKey is the context key of the reason for overriding the budget guard.
+type budgetOverrideKey struct{}
+
+// WithBudgetOverride returns a copy of ctx that lets calls exceed the budget of the budget guard. The reason is passed
+// to BudgetOptions.OnOverride; an empty reason does not override the budget.
+func WithBudgetOverride(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, budgetOverrideKey{}, reason)
+}
+
+// EnableBudgetGuard : Check the budget before ordering director sites or hosts
+// Before CreateWorkloadDomain and SetHostsCount are sent, the monthly price of the director site after the call is
+// quoted with GetVcddPrice. With an account limit, the other director sites of the account are quoted too, except
+// for those being deleted. A call that would exceed a limit fails with a *BudgetExceededError unless its context
+// was made with WithBudgetOverride. A call fails too when its price cannot be quoted.
+func (vmware *VmwareV1) EnableBudgetGuard(options *BudgetOptions) error {
+	err := core.ValidateNotNil(options, "options cannot be nil")
+	if err != nil {
+		return err
+	}
+	if options.SiteLimit < 0 || options.AccountLimit < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	if options.SiteLimit == 0 && options.AccountLimit == 0 {
+		return fmt.Errorf("at least one of options.SiteLimit and options.AccountLimit must be set")
+	}
+	resolved := *options
+	vmware.budget = &resolved
+	return nil
+}
+
+// DisableBudgetGuard : Stop checking the budget
+func (vmware *VmwareV1) DisableBudgetGuard() {
+	vmware.budget = nil
+}
+
+// budgeted reports whether the budget guard checks the operation.
+func budgeted(operation string) bool {
+	for _, candidate := range BudgetedOperations {
+		if candidate == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBudget returns a *BudgetExceededError when the request of the operation would exceed the budget.
+func (vmware *VmwareV1) checkBudget(operation string, request *http.Request) (err error) {
+	ctx := request.Context()
+	options := vmware.budget
+	defer func() {
+		if _, exceeded := err.(*BudgetExceededError); err != nil && !exceeded {
+			err = fmt.Errorf("checking the budget of %s: %w", operation, err)
+		}
+	}()
+
+	body, err := requestBody(request)
+	if err != nil {
+		return
+	}
+	var siteID, siteName string
+	var clusters []DirectorSitePriceQuoteClusterInfo
+	switch operation {
+	case "CreateWorkloadDomain":
+		var order struct {
+			Name     string             `json:"name"`
+			Clusters []ClusterOrderInfo `json:"clusters"`
+		}
+		if err = json.Unmarshal(body, &order); err != nil {
+			return
+		}
+		siteName = order.Name
+		for _, cluster := range order.Clusters {
+			clusters = append(clusters, DirectorSitePriceQuoteClusterInfo{
+				Name:        cluster.Name,
+				HostProfile: cluster.HostProfile,
+				HostCount:   cluster.HostCount,
+				FileShares:  cluster.FileShares,
+			})
+		}
+	case "SetHostsCount":
+		var hostsCount struct {
+			Count *int64 `json:"count"`
+		}
+		if err = json.Unmarshal(body, &hostsCount); err != nil {
+			return
+		}
+		params := resourceParams(operation, request)
+		siteID = params["site_id"]
+		var site *DirectorSite
+		site, _, err = vmware.GetSpecificWorkloadDomainInstanceWithContext(ctx, vmware.NewGetSpecificWorkloadDomainInstanceOptions(siteID))
+		if err != nil {
+			return
+		}
+		siteName = core.StringNilMapper(site.Name)
+		clusters, err = siteQuoteClusters(site)
+		if err != nil {
+			return
+		}
+		for i := range clusters {
+			if core.StringNilMapper(site.Clusters[i].ID) == params["cluster_id"] {
+				clusters[i].HostCount = hostsCount.Count
+			}
+		}
+	}
+
+	sitePrice, currency, err := vmware.quoteMonthlyPrice(ctx, clusters)
+	if err != nil {
+		return
+	}
+	exceeded := func(scope string, price float64, limit float64) *BudgetExceededError {
+		return &BudgetExceededError{Operation: operation, SiteID: siteID, SiteName: siteName, Scope: scope, Price: price, Limit: limit, Currency: currency}
+	}
+	var rejection *BudgetExceededError
+	if options.SiteLimit > 0 && sitePrice > options.SiteLimit {
+		rejection = exceeded(BudgetScopeSite, sitePrice, options.SiteLimit)
+	}
+	if rejection == nil && options.AccountLimit > 0 {
+		var accountPrice float64
+		accountPrice, err = vmware.accountMonthlyPrice(ctx, siteID)
+		if err != nil {
+			return
+		}
+		if accountPrice += sitePrice; accountPrice > options.AccountLimit {
+			rejection = exceeded(BudgetScopeAccount, accountPrice, options.AccountLimit)
+		}
+	}
+	if rejection == nil {
+		return nil
+	}
+	if reason, ok := ctx.Value(budgetOverrideKey{}).(string); ok && reason != "" {
+		if options.OnOverride != nil {
+			options.OnOverride(ctx, rejection, reason)
+		}
+		return nil
+	}
+	return rejection
+}
+
+// accountMonthlyPrice returns the monthly price of the director sites of the account other than the excluded one.
+func (vmware *VmwareV1) accountMonthlyPrice(ctx context.Context, excludedSiteID string) (total float64, err error) {
+	sites, _, err := vmware.ListWorkloadDomainInstancesWithContext(ctx, vmware.NewListWorkloadDomainInstancesOptions())
+	if err != nil {
+		return
+	}
+	for i := range sites.DirectorSites {
+		site := &sites.DirectorSites[i]
+		switch core.StringNilMapper(site.Status) {
+		case DirectorSite_Status_Deleting, DirectorSite_Status_Deleted:
+			continue
+		}
+		if core.StringNilMapper(site.ID) == excludedSiteID {
+			continue
+		}
+		clusters, err := siteQuoteClusters(site)
+		if err != nil {
+			return 0, err
+		}
+		price, _, err := vmware.quoteMonthlyPrice(ctx, clusters)
+		if err != nil {
+			return 0, err
+		}
+		total += price
+	}
+	return
+}
+
+// quoteMonthlyPrice returns the monthly price of a director site with the clusters, quoted with GetVcddPrice.
+func (vmware *VmwareV1) quoteMonthlyPrice(ctx context.Context, clusters []DirectorSitePriceQuoteClusterInfo) (price float64, currency string, err error) {
+	options := vmware.NewGetVcddPriceOptions().SetClusters(clusters)
+	if vmware.budget.Country != "" {
+		options.SetCountry(vmware.budget.Country)
+	}
+	quote, _, err := vmware.GetVcddPriceWithContext(ctx, options)
+	if err != nil {
+		return
+	}
+	currency = core.StringNilMapper(quote.Currency)
+	if vmware.budget.Currency != "" && currency != vmware.budget.Currency {
+		err = fmt.Errorf("the price is quoted in %s, not in the budget currency %s", currency, vmware.budget.Currency)
+		return
+	}
+	price = float64Value(quote.Total)
+	return
+}
+
+// siteQuoteClusters returns the clusters of the director site as quoted by GetVcddPrice, in the order of
+// DirectorSite.Clusters. File shares of storage tiers unknown to this version of the SDK are not quoted. An error is
+// returned when the file shares of a cluster cannot be decoded, since quoting them as empty would under-price the
+// director site.
+func siteQuoteClusters(site *DirectorSite) ([]DirectorSitePriceQuoteClusterInfo, error) {
+	clusters := make([]DirectorSitePriceQuoteClusterInfo, len(site.Clusters))
+	for i := range site.Clusters {
+		summary := &site.Clusters[i]
+		fileShares, err := summary.FileShareSizes()
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s of director site %s: %w", core.StringNilMapper(summary.ID), core.StringNilMapper(site.ID), err)
+		}
+		clusters[i] = DirectorSitePriceQuoteClusterInfo{
+			Name:        summary.Name,
+			HostProfile: summary.HostProfile,
+			HostCount:   summary.HostCount,
+			FileShares:  fileShares.FileShares(),
+		}
+	}
+	return clusters, nil
+}
+
+// requestBody returns the body of the request, decompressed when it is gzip-compressed. The body is left to be sent;
+// a body that cannot be read twice is buffered for that.
+func requestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	if request.GetBody == nil {
+		content, err := io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(content)), nil
+		}
+		request.Body, _ = request.GetBody()
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if request.Header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(reader)
+	}
+	return content, nil
+}
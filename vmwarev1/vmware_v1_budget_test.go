@@ -0,0 +1,149 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 budget guard`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		var err error
+		vmwareService, err = server.NewClient()
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	// A director site with one cluster of two hosts costs 1500 + 2 * 3000 + 1000 * 0.12 = 7620 USD a month.
+	createSite := func(ctx context.Context, name string) (*vmwarev1.DirectorSite, error) {
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		site, _, err := vmwareService.CreateWorkloadDomainWithContext(ctx, vmwareService.NewCreateWorkloadDomainOptions(name, "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+		server.CompletePending()
+		return site, err
+	}
+
+	It(`Reject a resize over the site budget unless it is overridden`, func() {
+		var overrides []string
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{
+			SiteLimit: 10000,
+			Currency:  "USD",
+			OnOverride: func(ctx context.Context, exceeded *vmwarev1.BudgetExceededError, reason string) {
+				overrides = append(overrides, reason)
+			},
+		})).To(Succeed())
+		site, err := createSite(context.Background(), "site1")
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(1))
+
+		// A third host raises the price to 10620 USD.
+		setOptions := vmwareService.NewSetHostsCountOptions(*site.ID, *site.Clusters[0].ID, 3)
+		_, _, err = vmwareService.SetHostsCount(setOptions)
+		var exceeded *vmwarev1.BudgetExceededError
+		Expect(errors.As(err, &exceeded)).To(BeTrue())
+		Expect(exceeded.Operation).To(Equal("SetHostsCount"))
+		Expect(exceeded.SiteID).To(Equal(*site.ID))
+		Expect(exceeded.SiteName).To(Equal("site1"))
+		Expect(exceeded.Scope).To(Equal(vmwarev1.BudgetScopeSite))
+		Expect(exceeded.Price).To(BeNumerically("~", 10620))
+		Expect(exceeded.Limit).To(Equal(10000.0))
+		Expect(exceeded.Currency).To(Equal("USD"))
+		Expect(err.Error()).To(Equal("SetHostsCount of director site site1 would raise the monthly site price to 10620.00 USD, over the budget of 10000.00 USD"))
+		Expect(server.RequestCount("SetHostsCount")).To(Equal(0))
+
+		ctx := vmwarev1.WithBudgetOverride(context.Background(), "approved by the change board")
+		_, _, err = vmwareService.SetHostsCountWithContext(ctx, setOptions)
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("SetHostsCount")).To(Equal(1))
+		Expect(overrides).To(Equal([]string{"approved by the change board"}))
+	})
+	It(`Reject a director site over the account budget`, func() {
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{AccountLimit: 12000})).To(Succeed())
+		_, err := createSite(context.Background(), "site1")
+		Expect(err).To(BeNil())
+
+		_, err = createSite(context.Background(), "site2")
+		var exceeded *vmwarev1.BudgetExceededError
+		Expect(errors.As(err, &exceeded)).To(BeTrue())
+		Expect(exceeded.Scope).To(Equal(vmwarev1.BudgetScopeAccount))
+		Expect(exceeded.SiteID).To(BeEmpty())
+		Expect(exceeded.SiteName).To(Equal("site2"))
+		Expect(exceeded.Price).To(BeNumerically("~", 2*7620))
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(1))
+
+		vmwareService.DisableBudgetGuard()
+		_, err = createSite(context.Background(), "site2")
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(2))
+	})
+	It(`Fail the call when its price cannot be quoted`, func() {
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{SiteLimit: 10000})).To(Succeed())
+		server.InjectFault("GetVcddPrice", vmwarev1fake.Fault{StatusCode: 503, Times: 1})
+
+		_, err := createSite(context.Background(), "site1")
+		var apiErr *vmwarev1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(err.Error()).To(HavePrefix("checking the budget of CreateWorkloadDomain: "))
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(0))
+
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{SiteLimit: 10000, Currency: "EUR"})).To(Succeed())
+		_, err = createSite(context.Background(), "site1")
+		Expect(err).ToNot(BeNil())
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(0))
+	})
+	It(`Reject the call when the file shares of the director site cannot be decoded`, func() {
+		var paths []string
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			paths = append(paths, req.Method+" "+req.URL.Path)
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"id": "site1", "name": "site1", "clusters": [{"id": "cluster1", "host_count": 2, "host_profile": "BM_2S_20_CORES_192_GB", "file_shares": {"STORAGE_TWO_IOPS_GB": 0.5}}]}`)
+		}))
+		defer testServer.Close()
+		liveService, err := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL + "/v1",
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		Expect(liveService.EnableBudgetGuard(&vmwarev1.BudgetOptions{SiteLimit: 10000})).To(Succeed())
+
+		_, _, err = liveService.SetHostsCount(liveService.NewSetHostsCountOptions("site1", "cluster1", 3))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("checking the budget of SetHostsCount: cluster cluster1 of director site site1: "))
+		Expect(paths).To(Equal([]string{"GET /v1/director_sites/site1"}))
+	})
+	It(`Invoke EnableBudgetGuard with error: invalid options`, func() {
+		Expect(vmwareService.EnableBudgetGuard(nil)).ToNot(Succeed())
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{})).ToNot(Succeed())
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{SiteLimit: -1, AccountLimit: 1})).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,271 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultCacheTTL is how long the responses of the cached operations are kept when CacheOptions does not say.
+const DefaultCacheTTL = 10 * time.Minute
+
+// CachedOperations are the operations that can be cached. They return reference data that rarely changes.
+var CachedOperations = []string{"GetRegions", "ViewInstance", "ListPrices"}
+
+// CacheOptions : Options for the response cache of VmwareV1
+type CacheOptions struct {
+	// The time to live of the responses of each operation, keyed by operationId. Operations of CachedOperations that
+	// are missing use DefaultCacheTTL, and a zero or negative TTL disables the cache for the operation.
+	TTLs map[string]time.Duration
+
+	// The clock used for expiry. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// CacheStats : Statistics of the response cache for an operation
+type CacheStats struct {
+	// Calls answered from the cache.
+	Hits int64
+
+	// Calls that sent a request to the service.
+	Misses int64
+
+	// Calls that waited for an identical call in flight and shared its response instead of sending a request.
+	Shared int64
+}
+
+// EnableCache : Cache the responses of GetRegions, ViewInstance and ListPrices
+// Successful responses are kept for the TTL of their operation and returned to later identical calls, which are
+// calls of the same operation with the same URL and Accept-Language header. Concurrent identical calls send a single
+// request and all receive its outcome, unless the context of the call that sent the request ends first: then one of
+// the waiting calls sends it again. Errors are never cached. Cache hits send no request, so the tracing, metrics
+// and logging hooks are not invoked for them, and their responses carry no X-Global-Transaction-ID.
+//
+// Enabling the cache again replaces it, dropping the cached responses and statistics.
+func (vmware *VmwareV1) EnableCache(options *CacheOptions) {
+	resolved := CacheOptions{}
+	if options != nil {
+		resolved = *options
+	}
+	if resolved.Now == nil {
+		resolved.Now = time.Now
+	}
+	ttls := make(map[string]time.Duration)
+	for _, operation := range CachedOperations {
+		ttl, ok := resolved.TTLs[operation]
+		if !ok {
+			ttl = DefaultCacheTTL
+		}
+		if ttl > 0 {
+			ttls[operation] = ttl
+		}
+	}
+	vmware.cache = &responseCache{
+		ttls:     ttls,
+		now:      resolved.Now,
+		entries:  make(map[string]*cacheEntry),
+		inflight: make(map[string]*cacheCall),
+		stats:    make(map[string]*CacheStats),
+	}
+}
+
+// DisableCache : Stop caching responses and drop the cached ones
+func (vmware *VmwareV1) DisableCache() {
+	vmware.cache = nil
+}
+
+// InvalidateCache : Drop the cached responses of the operations, or of every operation when none is given
+// Calls in flight are not affected.
+func (vmware *VmwareV1) InvalidateCache(operations ...string) {
+	if vmware.cache != nil {
+		vmware.cache.invalidate(operations)
+	}
+}
+
+// CacheStats : The statistics of the response cache, keyed by operationId
+// The statistics are empty when the cache is not enabled.
+func (vmware *VmwareV1) CacheStats() map[string]CacheStats {
+	stats := make(map[string]CacheStats)
+	if vmware.cache != nil {
+		vmware.cache.mutex.Lock()
+		defer vmware.cache.mutex.Unlock()
+		for operation, operationStats := range vmware.cache.stats {
+			stats[operation] = *operationStats
+		}
+	}
+	return stats
+}
+
+// responseCache : The cached responses of a VmwareV1
+type responseCache struct {
+	ttls map[string]time.Duration
+	now  func() time.Time
+
+	mutex    sync.Mutex
+	entries  map[string]*cacheEntry
+	inflight map[string]*cacheCall
+	stats    map[string]*CacheStats
+}
+
+// cacheEntry : A cached response
+type cacheEntry struct {
+	operation string
+	response  *core.DetailedResponse
+	result    map[string]json.RawMessage
+	expires   time.Time
+}
+
+// cacheCall : A call in flight that identical calls wait for
+type cacheCall struct {
+	done     chan struct{}
+	response *core.DetailedResponse
+	result   map[string]json.RawMessage
+	err      error
+
+	// Whether the call failed because the context of its caller ended.
+	abandoned bool
+}
+
+// caches reports whether the responses of the operation are cached.
+func (cache *responseCache) caches(operation string) bool {
+	_, ok := cache.ttls[operation]
+	return ok
+}
+
+// do answers the request from the cache, from an identical call in flight, or with send.
+func (cache *responseCache) do(operation string, request *http.Request, result interface{}, send func(operation string, request *http.Request, result interface{}) (*core.DetailedResponse, error)) (response *core.DetailedResponse, err error) {
+	raw, ok := result.(*map[string]json.RawMessage)
+	if !ok {
+		return send(operation, request, result)
+	}
+	key := operation + " " + request.URL.String() + " " + request.Header.Get("Accept-Language")
+
+	for {
+		cache.mutex.Lock()
+		stats := cache.statsOf(operation)
+		if entry, ok := cache.entries[key]; ok && cache.now().Before(entry.expires) {
+			stats.Hits++
+			cache.mutex.Unlock()
+			return entry.reuse(raw)
+		}
+		call, ok := cache.inflight[key]
+		if !ok {
+			break
+		}
+		stats.Shared++
+		cache.mutex.Unlock()
+		select {
+		case <-call.done:
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+		// The context of the caller that sent the request ended, which says nothing about this call, so it is made
+		// again, by the first waiting call to get here.
+		if call.abandoned {
+			continue
+		}
+		if call.err != nil {
+			return call.response, call.err
+		}
+		return (&cacheEntry{response: call.response, result: call.result}).reuse(raw)
+	}
+	cache.statsOf(operation).Misses++
+	call := &cacheCall{done: make(chan struct{})}
+	cache.inflight[key] = call
+	cache.mutex.Unlock()
+
+	response, err = send(operation, request, raw)
+	call.response, call.result, call.err = response, *raw, err
+	call.abandoned = err != nil && request.Context().Err() != nil
+
+	cache.mutex.Lock()
+	delete(cache.inflight, key)
+	if err == nil {
+		cache.entries[key] = &cacheEntry{
+			operation: operation,
+			response:  response,
+			result:    *raw,
+			expires:   cache.now().Add(cache.ttls[operation]),
+		}
+	}
+	cache.mutex.Unlock()
+	close(call.done)
+
+	if err != nil {
+		return
+	}
+	// The caller owns response and raw, so the entry keeps copies.
+	return (&cacheEntry{response: response, result: *raw}).copyTo(raw)
+}
+
+// statsOf returns the statistics of the operation. The cache must be locked.
+func (cache *responseCache) statsOf(operation string) *CacheStats {
+	stats, ok := cache.stats[operation]
+	if !ok {
+		stats = &CacheStats{}
+		cache.stats[operation] = stats
+	}
+	return stats
+}
+
+func (cache *responseCache) invalidate(operations []string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if len(operations) == 0 {
+		cache.entries = make(map[string]*cacheEntry)
+		return
+	}
+	invalidated := make(map[string]bool)
+	for _, operation := range operations {
+		invalidated[operation] = true
+	}
+	for key, entry := range cache.entries {
+		if invalidated[entry.operation] {
+			delete(cache.entries, key)
+		}
+	}
+}
+
+// reuse returns copyTo for a call that sent no request of its own. The X-Global-Transaction-ID of the response
+// belongs to the call that sent the request, so it is removed.
+func (entry *cacheEntry) reuse(raw *map[string]json.RawMessage) (*core.DetailedResponse, error) {
+	response, err := entry.copyTo(raw)
+	response.Headers.Del(transactionIDHeader)
+	return response, err
+}
+
+// copyTo copies the cached result to raw and returns a copy of the cached response, so callers never share them.
+func (entry *cacheEntry) copyTo(raw *map[string]json.RawMessage) (*core.DetailedResponse, error) {
+	var result map[string]json.RawMessage
+	if entry.result != nil {
+		result = make(map[string]json.RawMessage, len(entry.result))
+		for name, value := range entry.result {
+			result[name] = value
+		}
+	}
+	*raw = result
+
+	response := *entry.response
+	response.Headers = entry.response.Headers.Clone()
+	response.Result = raw
+	return &response, nil
+}
@@ -0,0 +1,248 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 cache`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+	var now time.Time
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		var err error
+		vmwareService, err = server.NewClient()
+		Expect(err).To(BeNil())
+		now = time.Now()
+		vmwareService.EnableCache(&vmwarev1.CacheOptions{
+			TTLs: map[string]time.Duration{"ListPrices": time.Hour, "ViewInstance": 0},
+			Now: func() time.Time {
+				return now
+			},
+		})
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Answer identical calls from the cache until the TTL expires`, func() {
+		first, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		second, response, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(second).To(Equal(first))
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(response.Result).To(Equal(second))
+		Expect(server.RequestCount("GetRegions")).To(Equal(1))
+
+		// Results are not shared between callers.
+		delete(second.DirectorSiteRegions, "us-south")
+		third, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(third.DirectorSiteRegions).To(HaveKey("us-south"))
+
+		// A different Accept-Language is a different call.
+		_, _, err = vmwareService.GetRegions(vmwareService.NewGetRegionsOptions().SetAcceptLanguage("de"))
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("GetRegions")).To(Equal(2))
+
+		now = now.Add(vmwarev1.DefaultCacheTTL)
+		_, _, err = vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("GetRegions")).To(Equal(3))
+
+		Expect(vmwareService.CacheStats()).To(Equal(map[string]vmwarev1.CacheStats{
+			"GetRegions": {Hits: 2, Misses: 3},
+		}))
+	})
+	It(`Leave out the transaction ID of the call that filled the cache`, func() {
+		vmwareService.EnableTransactionIDs(nil)
+		_, first, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(vmwarev1.TransactionID(first)).ToNot(BeEmpty())
+
+		_, second, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("GetRegions")).To(Equal(1))
+		Expect(vmwarev1.TransactionID(second)).To(BeEmpty())
+		Expect(vmwarev1.TransactionID(first)).ToNot(BeEmpty())
+	})
+	It(`Use the TTL of each operation`, func() {
+		for i := 0; i < 2; i++ {
+			_, _, err := vmwareService.ListPrices(vmwareService.NewListPricesOptions())
+			Expect(err).To(BeNil())
+			_, _, err = vmwareService.ViewInstance(vmwareService.NewViewInstanceOptions())
+			Expect(err).To(BeNil())
+			now = now.Add(30 * time.Minute)
+		}
+		Expect(server.RequestCount("ListPrices")).To(Equal(1))
+		Expect(server.RequestCount("ViewInstance")).To(Equal(2))
+		Expect(vmwareService.CacheStats()).ToNot(HaveKey("ViewInstance"))
+	})
+	It(`Never cache errors or other operations`, func() {
+		server.InjectFault("GetRegions", vmwarev1fake.Fault{StatusCode: 500, Times: 1})
+		_, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).ToNot(BeNil())
+		_, _, err = vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("GetRegions")).To(Equal(2))
+
+		for i := 0; i < 2; i++ {
+			_, _, err = vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+			Expect(err).To(BeNil())
+		}
+		Expect(server.RequestCount("ListVdcs")).To(Equal(2))
+	})
+	It(`Invalidate cached responses`, func() {
+		fetch := func() {
+			_, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+			Expect(err).To(BeNil())
+			_, _, err = vmwareService.ListPrices(vmwareService.NewListPricesOptions())
+			Expect(err).To(BeNil())
+		}
+		fetch()
+		vmwareService.InvalidateCache("ListPrices")
+		fetch()
+		Expect(server.RequestCount("GetRegions")).To(Equal(1))
+		Expect(server.RequestCount("ListPrices")).To(Equal(2))
+
+		vmwareService.InvalidateCache()
+		fetch()
+		Expect(server.RequestCount("GetRegions")).To(Equal(2))
+		Expect(server.RequestCount("ListPrices")).To(Equal(3))
+
+		vmwareService.DisableCache()
+		fetch()
+		Expect(server.RequestCount("GetRegions")).To(Equal(3))
+		Expect(vmwareService.CacheStats()).To(BeEmpty())
+	})
+	It(`Send a single request for concurrent identical calls`, func() {
+		var requests int32
+		release := make(chan struct{})
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			<-release
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"director_site_regions": {"us-south": {"endpoint": "https://example.com"}}}`)
+		}))
+		defer testServer.Close()
+		blockingService, err := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		blockingService.EnableCache(nil)
+
+		const calls = 5
+		var wg sync.WaitGroup
+		results := make([]*vmwarev1.DirectorSiteRegions, calls)
+		for i := 0; i < calls; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				result, _, err := blockingService.GetRegionsWithContext(context.Background(), blockingService.NewGetRegionsOptions())
+				Expect(err).To(BeNil())
+				results[i] = result
+			}(i)
+		}
+		Eventually(func() int64 {
+			return blockingService.CacheStats()["GetRegions"].Shared
+		}).Should(Equal(int64(calls - 1)))
+		close(release)
+		wg.Wait()
+
+		Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		for _, result := range results {
+			Expect(*result.DirectorSiteRegions["us-south"].Endpoint).To(Equal("https://example.com"))
+		}
+		Expect(blockingService.CacheStats()["GetRegions"]).To(Equal(vmwarev1.CacheStats{Misses: 1, Shared: calls - 1}))
+	})
+	It(`Send the request again when the context of the call that sent it ends`, func() {
+		var requests int32
+		release := make(chan struct{})
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			select {
+			case <-release:
+			case <-req.Context().Done():
+				return
+			}
+			res.Header().Set("Content-type", "application/json")
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"director_site_regions": {"us-south": {"endpoint": "https://example.com"}}}`)
+		}))
+		defer testServer.Close()
+		blockingService, err := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		blockingService.EnableCache(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, _, err := blockingService.GetRegionsWithContext(ctx, blockingService.NewGetRegionsOptions())
+			leaderErr <- err
+		}()
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&requests)
+		}).Should(Equal(int32(1)))
+
+		type outcome struct {
+			result *vmwarev1.DirectorSiteRegions
+			err    error
+		}
+		follower := make(chan outcome, 1)
+		go func() {
+			result, _, err := blockingService.GetRegionsWithContext(context.Background(), blockingService.NewGetRegionsOptions())
+			follower <- outcome{result, err}
+		}()
+		Eventually(func() int64 {
+			return blockingService.CacheStats()["GetRegions"].Shared
+		}).Should(Equal(int64(1)))
+
+		cancel()
+		Expect(<-leaderErr).ToNot(BeNil())
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&requests)
+		}).Should(Equal(int32(2)))
+		close(release)
+
+		followed := <-follower
+		Expect(followed.err).To(BeNil())
+		Expect(*followed.result.DirectorSiteRegions["us-south"].Endpoint).To(Equal("https://example.com"))
+		Expect(blockingService.CacheStats()["GetRegions"]).To(Equal(vmwarev1.CacheStats{Misses: 2, Shared: 1}))
+	})
+})
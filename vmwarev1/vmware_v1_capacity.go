@@ -0,0 +1,290 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// CapacityRequirements : The capacity that a cluster must provide to its workloads
+type CapacityRequirements struct {
+	// The name and data center location of the planned cluster, copied to the ClusterOrderInfo of each candidate.
+	Name     string
+	Location string
+
+	// The country whose prices are used to estimate the cost of the candidates, such as "USA".
+	Country string
+
+	// The vCPUs and the RAM in GB that the workloads need.
+	VCPUs int64
+	RamGB int64
+
+	// The raw capacity in GB of the local disks of the hosts that the workloads need. Zero when the workloads only
+	// use file shares.
+	LocalDiskGB int64
+
+	// The file shares that the workloads need, in GB per storage tier. Only the tiers of StorageTiers can be ordered.
+	Storage FileShareSizes
+
+	// The number of hosts kept in reserve for high availability. A cluster planned with one spare host (N+1) still
+	// meets the requirements when one of its hosts fails.
+	SpareHosts int64
+
+	// The number of vCPUs that run on each CPU of a host profile. Defaults to 1, which does not overcommit CPUs.
+	VCPUsPerCPU float64
+
+	// The largest number of hosts that a candidate may have, spare hosts included. Zero does not limit the number of
+	// hosts.
+	MaxHostCount int64
+}
+
+// ClusterCandidate : A cluster that meets capacity requirements
+type ClusterCandidate struct {
+	// The cluster to order, ready for CreateWorkloadDomain.
+	Cluster ClusterOrderInfo
+
+	// The host profile of the cluster.
+	HostProfile HostProfile
+
+	// The capacity of the cluster without its spare hosts.
+	VCPUs       int64
+	RamGB       int64
+	LocalDiskGB int64
+
+	// The estimated monthly cost of the cluster, hosts and file shares, in Currency. The director site base charge is
+	// not included; it is in Quote.
+	MonthlyCost float64
+	Currency    string
+
+	// The estimate of a director site with only this cluster.
+	Quote *DirectorSitePriceQuoteResponse
+}
+
+// RejectedHostProfile : A host profile that cannot meet capacity requirements
+type RejectedHostProfile struct {
+	// The name of the host profile.
+	HostProfile string
+
+	// Why no cluster with the host profile is a candidate.
+	Reason string
+}
+
+// CapacityPlan : The clusters that meet capacity requirements
+type CapacityPlan struct {
+	// One candidate per host profile that meets the requirements, cheapest first. Candidates of the same cost are
+	// ordered by number of hosts and then by host profile.
+	Candidates []ClusterCandidate
+
+	// The host profiles without a candidate, in catalog order.
+	Rejected []RejectedHostProfile
+}
+
+// PlanCluster : Plan a cluster that meets capacity requirements
+// Fetch the host profiles with ViewInstance and the price catalog with ListPrices, then plan the cluster with
+// PlanClusterCapacity.
+func (vmware *VmwareV1) PlanCluster(ctx context.Context, requirements *CapacityRequirements) (plan *CapacityPlan, err error) {
+	err = core.ValidateNotNil(requirements, "requirements cannot be nil")
+	if err != nil {
+		return
+	}
+	hostProfiles, _, err := vmware.ViewInstanceWithContext(ctx, vmware.NewViewInstanceOptions())
+	if err != nil {
+		return
+	}
+	pricing, _, err := vmware.ListPricesWithContext(ctx, vmware.NewListPricesOptions())
+	if err != nil {
+		return
+	}
+	estimator, err := NewPriceEstimator(pricing)
+	if err != nil {
+		return
+	}
+	return PlanClusterCapacity(requirements, hostProfiles, estimator)
+}
+
+// PlanClusterCapacity : Plan a cluster that meets capacity requirements offline
+// For each host profile, the number of hosts is the smallest that provides the vCPUs, RAM and local disk capacity of
+// the requirements without the spare hosts, plus the spare hosts, and at least MinimumClusterHostCount. The file
+// shares of the requirements are ordered as they are. Every candidate is priced with the estimator. Host profiles
+// that would need more than MaxHostCount hosts, lack CPU or RAM details, or have no price in the country are
+// rejected.
+func PlanClusterCapacity(requirements *CapacityRequirements, hostProfiles *ListHostProfiles, estimator *PriceEstimator) (plan *CapacityPlan, err error) {
+	err = core.ValidateNotNil(requirements, "requirements cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateNotNil(hostProfiles, "hostProfiles cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateNotNil(estimator, "estimator cannot be nil")
+	if err != nil {
+		return
+	}
+	err = requirements.validate()
+	if err != nil {
+		return
+	}
+	vcpusPerCPU := requirements.VCPUsPerCPU
+	if vcpusPerCPU == 0 {
+		vcpusPerCPU = 1
+	}
+
+	plan = &CapacityPlan{}
+	reject := func(profile string, format string, args ...interface{}) {
+		plan.Rejected = append(plan.Rejected, RejectedHostProfile{HostProfile: profile, Reason: fmt.Sprintf(format, args...)})
+	}
+	for _, profile := range hostProfiles.DirectorSiteHostProfiles {
+		name := core.StringNilMapper(profile.ProfileName)
+		cpus := int64Value(profile.CpuCount)
+		ram := int64Value(profile.Ram)
+		disk := localDiskGB(profile)
+		if cpus <= 0 || ram <= 0 {
+			reject(name, "the host profile has no CPU count or RAM")
+			continue
+		}
+		vcpus := int64(float64(cpus) * vcpusPerCPU)
+		if vcpus <= 0 {
+			reject(name, "a host runs no vCPUs at %g vCPUs per CPU", vcpusPerCPU)
+			continue
+		}
+		if requirements.LocalDiskGB > 0 && disk == 0 {
+			reject(name, "the host profile has no local disks")
+			continue
+		}
+
+		hosts := hostsFor(requirements.VCPUs, vcpus)
+		if n := hostsFor(requirements.RamGB, ram); n > hosts {
+			hosts = n
+		}
+		if requirements.LocalDiskGB > 0 {
+			if n := hostsFor(requirements.LocalDiskGB, disk); n > hosts {
+				hosts = n
+			}
+		}
+		hosts += requirements.SpareHosts
+		if hosts < MinimumClusterHostCount {
+			hosts = MinimumClusterHostCount
+		}
+		if requirements.MaxHostCount > 0 && hosts > requirements.MaxHostCount {
+			reject(name, "%d hosts are needed, more than the maximum of %d", hosts, requirements.MaxHostCount)
+			continue
+		}
+
+		candidate := ClusterCandidate{
+			Cluster: ClusterOrderInfo{
+				Name:        core.StringPtr(requirements.Name),
+				Location:    core.StringPtr(requirements.Location),
+				HostCount:   core.Int64Ptr(hosts),
+				FileShares:  requirements.Storage.FileShares(),
+				HostProfile: core.StringPtr(name),
+			},
+			HostProfile: profile,
+			VCPUs:       vcpus * (hosts - requirements.SpareHosts),
+			RamGB:       ram * (hosts - requirements.SpareHosts),
+			LocalDiskGB: disk * (hosts - requirements.SpareHosts),
+		}
+		quote, err := estimator.Estimate(requirements.Country, []DirectorSitePriceQuoteClusterInfo{{
+			Name:        candidate.Cluster.Name,
+			HostProfile: candidate.Cluster.HostProfile,
+			HostCount:   candidate.Cluster.HostCount,
+			FileShares:  candidate.Cluster.FileShares,
+		}})
+		var missingPrice *MissingPriceError
+		if errors.As(err, &missingPrice) {
+			reject(name, "%s", missingPrice.Error())
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		candidate.Quote = quote
+		candidate.MonthlyCost = *quote.Clusters[0].Price
+		candidate.Currency = core.StringNilMapper(quote.Currency)
+		plan.Candidates = append(plan.Candidates, candidate)
+	}
+
+	sort.SliceStable(plan.Candidates, func(i, j int) bool {
+		a, b := plan.Candidates[i], plan.Candidates[j]
+		if a.MonthlyCost != b.MonthlyCost {
+			return a.MonthlyCost < b.MonthlyCost
+		}
+		if *a.Cluster.HostCount != *b.Cluster.HostCount {
+			return *a.Cluster.HostCount < *b.Cluster.HostCount
+		}
+		return *a.Cluster.HostProfile < *b.Cluster.HostProfile
+	})
+	return
+}
+
+// validate checks that the requirements can be planned.
+func (requirements *CapacityRequirements) validate() error {
+	if requirements.Country == "" {
+		return fmt.Errorf("country cannot be empty")
+	}
+	for _, value := range []struct {
+		name  string
+		value int64
+	}{
+		{"vCPUs", requirements.VCPUs},
+		{"RAM", requirements.RamGB},
+		{"local disk capacity", requirements.LocalDiskGB},
+		{"spare hosts", requirements.SpareHosts},
+		{"maximum host count", requirements.MaxHostCount},
+	} {
+		if value.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", value.name, value.value)
+		}
+	}
+	if requirements.VCPUsPerCPU < 0 {
+		return fmt.Errorf("vCPUs per CPU must not be negative, got %g", requirements.VCPUsPerCPU)
+	}
+	for tier, size := range requirements.Storage {
+		if size < 0 {
+			return fmt.Errorf("storage %s must not be negative, got %d", tier, size)
+		}
+	}
+	if unknown := requirements.Storage.Unknown(); len(unknown) > 0 {
+		return fmt.Errorf("storage tiers %v cannot be ordered; use the tiers of StorageTiers", unknown.Tiers())
+	}
+	return nil
+}
+
+// localDiskGB returns the raw capacity in GB of the local disks of a host of the profile.
+func localDiskGB(profile HostProfile) (size int64) {
+	for _, disk := range profile.LocalDisks {
+		size += int64Value(disk.Quantity) * int64Value(disk.Size)
+	}
+	return
+}
+
+// hostsFor returns the number of hosts of the given capacity that provide the needed capacity.
+func hostsFor(needed int64, perHost int64) int64 {
+	return (needed + perHost - 1) / perHost
+}
+
+// int64Value returns the value of an optional number, or zero when it is not set.
+func int64Value(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,146 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 capacity planner`, func() {
+	hostProfiles := &vmwarev1.ListHostProfiles{DirectorSiteHostProfiles: vmwarev1fake.DefaultHostProfiles()}
+	estimator, estimatorErr := vmwarev1.NewPriceEstimator(&vmwarev1.DirectorSitePricingInfo{DirectorSitePricing: vmwarev1fake.DefaultPricing()})
+	requirements := func() *vmwarev1.CapacityRequirements {
+		return &vmwarev1.CapacityRequirements{
+			Name:       "cluster1",
+			Location:   "dal10",
+			Country:    vmwarev1fake.DefaultCountry,
+			VCPUs:      160,
+			RamGB:      1000,
+			Storage:    vmwarev1.FileShareSizes{vmwarev1.StorageTierTwoIopsGB: 1000},
+			SpareHosts: 1,
+		}
+	}
+	profiles := func(plan *vmwarev1.CapacityPlan) []string {
+		var names []string
+		for _, candidate := range plan.Candidates {
+			names = append(names, *candidate.Cluster.HostProfile)
+		}
+		return names
+	}
+
+	It(`Rank the host profiles that meet the requirements by monthly cost`, func() {
+		Expect(estimatorErr).To(BeNil())
+		plan, err := vmwarev1.PlanClusterCapacity(requirements(), hostProfiles, estimator)
+		Expect(err).To(BeNil())
+		Expect(plan.Rejected).To(BeEmpty())
+		Expect(profiles(plan)).To(Equal([]string{vmwarev1fake.HostProfile384GB, vmwarev1fake.HostProfile192GB, vmwarev1fake.HostProfile768GB}))
+
+		// Three 384 GB hosts provide the RAM, plus one spare host.
+		best := plan.Candidates[0]
+		Expect(*best.Cluster.Name).To(Equal("cluster1"))
+		Expect(*best.Cluster.Location).To(Equal("dal10"))
+		Expect(*best.Cluster.HostCount).To(Equal(int64(4)))
+		Expect(*best.Cluster.FileShares.STORAGETWOIOPSGB).To(Equal(int64(1000)))
+		Expect(best.VCPUs).To(Equal(int64(168)))
+		Expect(best.RamGB).To(Equal(int64(1152)))
+		Expect(best.MonthlyCost).To(BeNumerically("~", 4*4200+1000*0.12))
+		Expect(best.Currency).To(Equal(vmwarev1fake.DefaultCurrency))
+		Expect(*best.Quote.Total).To(BeNumerically("~", best.MonthlyCost+1500))
+
+		Expect(*plan.Candidates[1].Cluster.HostCount).To(Equal(int64(7)))
+		Expect(*plan.Candidates[2].Cluster.HostCount).To(Equal(int64(4)))
+	})
+	It(`Size clusters by CPU with overcommitment and by local disks`, func() {
+		cpuBound := requirements()
+		cpuBound.RamGB = 0
+		cpuBound.VCPUs = 400
+		cpuBound.VCPUsPerCPU = 4
+		plan, err := vmwarev1.PlanClusterCapacity(cpuBound, hostProfiles, estimator)
+		Expect(err).To(BeNil())
+		// 160 vCPUs per 192 GB host: three hosts plus the spare host.
+		Expect(*plan.Candidates[0].Cluster.HostProfile).To(Equal(vmwarev1fake.HostProfile192GB))
+		Expect(*plan.Candidates[0].Cluster.HostCount).To(Equal(int64(4)))
+
+		diskBound := requirements()
+		diskBound.VCPUs, diskBound.RamGB, diskBound.SpareHosts = 0, 0, 0
+		diskBound.LocalDiskGB = 5000
+		plan, err = vmwarev1.PlanClusterCapacity(diskBound, hostProfiles, estimator)
+		Expect(err).To(BeNil())
+		Expect(*plan.Candidates[0].Cluster.HostCount).To(Equal(int64(3)))
+		Expect(plan.Candidates[0].LocalDiskGB).To(Equal(int64(5760)))
+	})
+	It(`Plan at least the minimum number of hosts`, func() {
+		small := requirements()
+		small.VCPUs, small.RamGB, small.SpareHosts = 1, 1, 0
+		plan, err := vmwarev1.PlanClusterCapacity(small, hostProfiles, estimator)
+		Expect(err).To(BeNil())
+		for _, candidate := range plan.Candidates {
+			Expect(*candidate.Cluster.HostCount).To(Equal(int64(vmwarev1.MinimumClusterHostCount)))
+		}
+	})
+	It(`Reject host profiles over the maximum host count or without a price`, func() {
+		limited := requirements()
+		limited.MaxHostCount = 5
+		catalog := &vmwarev1.ListHostProfiles{DirectorSiteHostProfiles: append(vmwarev1fake.DefaultHostProfiles(), vmwarev1.HostProfile{
+			ProfileName: core.StringPtr("BM_UNPRICED"),
+			CpuCount:    core.Int64Ptr(96),
+			Ram:         core.Int64Ptr(1536),
+		})}
+		plan, err := vmwarev1.PlanClusterCapacity(limited, catalog, estimator)
+		Expect(err).To(BeNil())
+		Expect(profiles(plan)).To(Equal([]string{vmwarev1fake.HostProfile384GB, vmwarev1fake.HostProfile768GB}))
+		Expect(plan.Rejected).To(HaveLen(2))
+		Expect(plan.Rejected[0].HostProfile).To(Equal(vmwarev1fake.HostProfile192GB))
+		Expect(plan.Rejected[0].Reason).To(Equal("7 hosts are needed, more than the maximum of 5"))
+		Expect(plan.Rejected[1].HostProfile).To(Equal("BM_UNPRICED"))
+		Expect(plan.Rejected[1].Reason).To(ContainSubstring("no price for metric BM_UNPRICED"))
+	})
+	It(`Invoke PlanClusterCapacity with error: invalid requirements`, func() {
+		_, err := vmwarev1.PlanClusterCapacity(nil, hostProfiles, estimator)
+		Expect(err).ToNot(BeNil())
+		noCountry := requirements()
+		noCountry.Country = ""
+		_, err = vmwarev1.PlanClusterCapacity(noCountry, hostProfiles, estimator)
+		Expect(err).ToNot(BeNil())
+		negative := requirements()
+		negative.SpareHosts = -1
+		_, err = vmwarev1.PlanClusterCapacity(negative, hostProfiles, estimator)
+		Expect(err).ToNot(BeNil())
+		unknownTier := requirements()
+		unknownTier.Storage["STORAGE_FUTURE_TIER"] = 100
+		_, err = vmwarev1.PlanClusterCapacity(unknownTier, hostProfiles, estimator)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Plan a cluster with the catalogs of the service`, func() {
+		server := vmwarev1fake.NewServer(nil)
+		defer server.Close()
+		vmwareService, err := server.NewClient()
+		Expect(err).To(BeNil())
+
+		plan, err := vmwareService.PlanCluster(context.Background(), requirements())
+		Expect(err).To(BeNil())
+		Expect(profiles(plan)).To(HaveLen(3))
+		Expect(server.RequestCount("ViewInstance")).To(Equal(1))
+		Expect(server.RequestCount("ListPrices")).To(Equal(1))
+	})
+})
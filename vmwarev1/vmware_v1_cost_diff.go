@@ -0,0 +1,273 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ClusterChange : A proposed change of the host count or the file shares of a cluster
+type ClusterChange struct {
+	// The proposed number of hosts, as passed to SetHostsCount. Nil keeps the current number of hosts.
+	HostCount *int64
+
+	// The proposed file shares, as passed to SetFileShares: the sizes of every storage tier after the change. Nil
+	// keeps the current file shares.
+	FileShares FileShareSizes
+}
+
+// CostChange : The change of the monthly cost of an item or sub-item of a price quote
+type CostChange struct {
+	// The name of the item, such as PriceItemHosts.
+	Item string
+
+	// The metric of a sub-item, such as a host profile or a storage tier. Empty for the item itself.
+	Metric string
+
+	// The number of units charged for a sub-item before and after the change, such as hosts or GB. Zero for items.
+	CountBefore int64
+	CountAfter  int64
+
+	// The monthly cost before and after the change, and the difference.
+	Before float64
+	After  float64
+	Delta  float64
+}
+
+// ClusterCostDiff : The change of the monthly cost of a cluster
+type ClusterCostDiff struct {
+	// The currency of every cost of the diff.
+	Currency string
+
+	// The monthly cost of the cluster before and after the change, and the difference. The director site base charge
+	// does not change with the cluster and is not included.
+	Before float64
+	After  float64
+	Delta  float64
+
+	// The changes of the items of the quotes, each item followed by its sub-items, in the order of the quotes.
+	Items []CostChange
+
+	// The quotes of a director site with only the cluster, before and after the change.
+	BeforeQuote *DirectorSitePriceQuoteResponse
+	AfterQuote  *DirectorSitePriceQuoteResponse
+}
+
+// DiffClusterCost : Price a proposed change of a cluster with GetVcddPrice
+// Quote the cluster, as returned by GetSpecificClusterInstance, with GetVcddPrice before and after the change, and
+// diff the quotes with DiffPriceQuotes. An empty country leaves the billing country to the service.
+func (vmware *VmwareV1) DiffClusterCost(ctx context.Context, country string, cluster *Cluster, change *ClusterChange) (diff *ClusterCostDiff, err error) {
+	before, after, err := clusterChangeQuotes(cluster, change)
+	if err != nil {
+		return
+	}
+	quote := func(info DirectorSitePriceQuoteClusterInfo) (*DirectorSitePriceQuoteResponse, error) {
+		options := vmware.NewGetVcddPriceOptions().SetClusters([]DirectorSitePriceQuoteClusterInfo{info})
+		if country != "" {
+			options.SetCountry(country)
+		}
+		result, _, err := vmware.GetVcddPriceWithContext(ctx, options)
+		return result, err
+	}
+	beforeQuote, err := quote(before)
+	if err != nil {
+		return
+	}
+	afterQuote, err := quote(after)
+	if err != nil {
+		return
+	}
+	return DiffPriceQuotes(beforeQuote, afterQuote)
+}
+
+// DiffClusterCost : Price a proposed change of a cluster offline
+// Estimate the cluster, as returned by GetSpecificClusterInstance, in the country before and after the change, and
+// diff the estimates with DiffPriceQuotes.
+func (estimator *PriceEstimator) DiffClusterCost(country string, cluster *Cluster, change *ClusterChange) (diff *ClusterCostDiff, err error) {
+	before, after, err := clusterChangeQuotes(cluster, change)
+	if err != nil {
+		return
+	}
+	beforeQuote, err := estimator.Estimate(country, []DirectorSitePriceQuoteClusterInfo{before})
+	if err != nil {
+		return
+	}
+	afterQuote, err := estimator.Estimate(country, []DirectorSitePriceQuoteClusterInfo{after})
+	if err != nil {
+		return
+	}
+	return DiffPriceQuotes(beforeQuote, afterQuote)
+}
+
+// DiffPriceQuotes : Diff the quotes of a cluster before and after a change
+// Both quotes must price a single cluster in the same currency, as returned by GetVcddPrice or
+// PriceEstimator.Estimate. Items and sub-items are matched by name; an item missing from a quote costs nothing there.
+func DiffPriceQuotes(before *DirectorSitePriceQuoteResponse, after *DirectorSitePriceQuoteResponse) (diff *ClusterCostDiff, err error) {
+	err = core.ValidateNotNil(before, "before cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateNotNil(after, "after cannot be nil")
+	if err != nil {
+		return
+	}
+	if len(before.Clusters) != 1 || len(after.Clusters) != 1 {
+		err = fmt.Errorf("quotes must price a single cluster, got %d and %d", len(before.Clusters), len(after.Clusters))
+		return
+	}
+	currency := core.StringNilMapper(before.Currency)
+	if afterCurrency := core.StringNilMapper(after.Currency); afterCurrency != currency {
+		err = fmt.Errorf("quotes are in different currencies: %s and %s", currency, afterCurrency)
+		return
+	}
+
+	beforeCluster, afterCluster := before.Clusters[0], after.Clusters[0]
+	diff = &ClusterCostDiff{
+		Currency:    currency,
+		Before:      float64Value(beforeCluster.Price),
+		After:       float64Value(afterCluster.Price),
+		BeforeQuote: before,
+		AfterQuote:  after,
+	}
+	diff.Delta = diff.After - diff.Before
+
+	for _, name := range itemNames(beforeCluster.Items, afterCluster.Items) {
+		beforeItem, afterItem := findItem(beforeCluster.Items, name), findItem(afterCluster.Items, name)
+		change := CostChange{Item: name, Before: float64Value(beforeItem.Price), After: float64Value(afterItem.Price)}
+		change.Delta = change.After - change.Before
+		diff.Items = append(diff.Items, change)
+
+		for _, metric := range subItemNames(beforeItem.Items, afterItem.Items) {
+			beforeSubItem, afterSubItem := findSubItem(beforeItem.Items, metric), findSubItem(afterItem.Items, metric)
+			change := CostChange{
+				Item:        name,
+				Metric:      metric,
+				CountBefore: int64Value(beforeSubItem.Count),
+				CountAfter:  int64Value(afterSubItem.Count),
+			}
+			// The price of a sub-item is the price of a single unit.
+			change.Before = float64(change.CountBefore) * float64Value(beforeSubItem.Price)
+			change.After = float64(change.CountAfter) * float64Value(afterSubItem.Price)
+			change.Delta = change.After - change.Before
+			diff.Items = append(diff.Items, change)
+		}
+	}
+	return
+}
+
+// clusterChangeQuotes returns the quote requests of the cluster before and after the change.
+func clusterChangeQuotes(cluster *Cluster, change *ClusterChange) (before DirectorSitePriceQuoteClusterInfo, after DirectorSitePriceQuoteClusterInfo, err error) {
+	err = core.ValidateNotNil(cluster, "cluster cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateNotNil(change, "change cannot be nil")
+	if err != nil {
+		return
+	}
+	if change.HostCount != nil && *change.HostCount < 1 {
+		err = fmt.Errorf("host count must be at least 1, got %d", *change.HostCount)
+		return
+	}
+	fileShares, err := cluster.FileShareSizes()
+	if err != nil {
+		return
+	}
+	// File shares of unknown storage tiers cannot be quoted, so a diff without them would be wrong.
+	if unknown := fileShares.Unknown(); len(unknown) > 0 {
+		err = fmt.Errorf("the cluster has file shares of storage tiers %v that cannot be priced", unknown.Tiers())
+		return
+	}
+	if unknown := change.FileShares.Unknown(); len(unknown) > 0 {
+		err = fmt.Errorf("the change has file shares of storage tiers %v that cannot be priced", unknown.Tiers())
+		return
+	}
+
+	before = DirectorSitePriceQuoteClusterInfo{
+		Name:        cluster.Name,
+		HostProfile: cluster.HostProfile,
+		HostCount:   cluster.HostCount,
+		FileShares:  fileShares.FileShares(),
+	}
+	after = before
+	if change.HostCount != nil {
+		after.HostCount = core.Int64Ptr(*change.HostCount)
+	}
+	if change.FileShares != nil {
+		after.FileShares = change.FileShares.FileShares()
+	}
+	return
+}
+
+// itemNames returns the names of the items of both quotes, in order.
+func itemNames(before []PriceInfoClusterItem, after []PriceInfoClusterItem) (names []string) {
+	seen := make(map[string]bool)
+	for _, items := range [][]PriceInfoClusterItem{before, after} {
+		for _, item := range items {
+			if name := core.StringNilMapper(item.Name); !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return
+}
+
+// subItemNames returns the names of the sub-items of both quotes, in order.
+func subItemNames(before []PriceInfoClusterSubItem, after []PriceInfoClusterSubItem) (names []string) {
+	seen := make(map[string]bool)
+	for _, items := range [][]PriceInfoClusterSubItem{before, after} {
+		for _, item := range items {
+			if name := core.StringNilMapper(item.Name); !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return
+}
+
+// findItem returns the item with the name, or an empty item when there is none.
+func findItem(items []PriceInfoClusterItem, name string) PriceInfoClusterItem {
+	for _, item := range items {
+		if core.StringNilMapper(item.Name) == name {
+			return item
+		}
+	}
+	return PriceInfoClusterItem{}
+}
+
+// findSubItem returns the sub-item with the name, or an empty sub-item when there is none.
+func findSubItem(items []PriceInfoClusterSubItem, name string) PriceInfoClusterSubItem {
+	for _, item := range items {
+		if core.StringNilMapper(item.Name) == name {
+			return item
+		}
+	}
+	return PriceInfoClusterSubItem{}
+}
+
+// float64Value returns the value of an optional number, or zero when it is not set.
+func float64Value(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
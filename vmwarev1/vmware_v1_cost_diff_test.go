@@ -0,0 +1,129 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 cluster cost diff`, func() {
+	estimator, estimatorErr := vmwarev1.NewPriceEstimator(&vmwarev1.DirectorSitePricingInfo{DirectorSitePricing: vmwarev1fake.DefaultPricing()})
+	cluster := func() *vmwarev1.Cluster {
+		return &vmwarev1.Cluster{
+			ID:          core.StringPtr("testCluster"),
+			Name:        core.StringPtr("cluster1"),
+			HostCount:   core.Int64Ptr(2),
+			HostProfile: core.StringPtr(vmwarev1fake.HostProfile192GB),
+			FileShares:  map[string]interface{}{vmwarev1.StorageTierTwoIopsGB: float64(1000)},
+		}
+	}
+	find := func(diff *vmwarev1.ClusterCostDiff, item string, metric string) vmwarev1.CostChange {
+		for _, change := range diff.Items {
+			if change.Item == item && change.Metric == metric {
+				return change
+			}
+		}
+		Fail("no cost change for " + item + " " + metric)
+		return vmwarev1.CostChange{}
+	}
+
+	It(`Diff the cost of a scale-out at the lower price of the quantity tier`, func() {
+		Expect(estimatorErr).To(BeNil())
+		diff, err := estimator.DiffClusterCost(vmwarev1fake.DefaultCountry, cluster(), &vmwarev1.ClusterChange{HostCount: core.Int64Ptr(10)})
+		Expect(err).To(BeNil())
+		Expect(diff.Currency).To(Equal(vmwarev1fake.DefaultCurrency))
+		Expect(diff.Before).To(BeNumerically("~", 2*3000+1000*0.12))
+		Expect(diff.After).To(BeNumerically("~", 10*2700+1000*0.12))
+		Expect(diff.Delta).To(BeNumerically("~", 21000))
+
+		hosts := find(diff, vmwarev1.PriceItemHosts, vmwarev1fake.HostProfile192GB)
+		Expect(hosts.CountBefore).To(Equal(int64(2)))
+		Expect(hosts.CountAfter).To(Equal(int64(10)))
+		Expect(hosts.Delta).To(BeNumerically("~", 21000))
+		Expect(find(diff, vmwarev1.PriceItemStorage, "").Delta).To(BeNumerically("~", 0))
+		Expect(*diff.BeforeQuote.Clusters[0].Items[0].Items[0].Count).To(Equal(int64(2)))
+	})
+	It(`Diff the cost of a storage change per storage tier`, func() {
+		diff, err := estimator.DiffClusterCost(vmwarev1fake.DefaultCountry, cluster(), &vmwarev1.ClusterChange{
+			FileShares: vmwarev1.FileShareSizes{vmwarev1.StorageTierTwoIopsGB: 500, vmwarev1.StorageTierFourIopsGB: 1000},
+		})
+		Expect(err).To(BeNil())
+		Expect(diff.Delta).To(BeNumerically("~", 1000*0.2-500*0.12))
+
+		var metrics []string
+		for _, change := range diff.Items {
+			metrics = append(metrics, change.Item+" "+change.Metric)
+		}
+		Expect(metrics).To(Equal([]string{
+			"hosts ",
+			"hosts " + vmwarev1fake.HostProfile192GB,
+			"storage ",
+			"storage " + vmwarev1.StorageTierTwoIopsGB,
+			"storage " + vmwarev1.StorageTierFourIopsGB,
+		}))
+		Expect(find(diff, vmwarev1.PriceItemHosts, "").Delta).To(BeNumerically("~", 0))
+		fourIops := find(diff, vmwarev1.PriceItemStorage, vmwarev1.StorageTierFourIopsGB)
+		Expect(fourIops.CountBefore).To(Equal(int64(0)))
+		Expect(fourIops.CountAfter).To(Equal(int64(1000)))
+		Expect(fourIops.After).To(BeNumerically("~", 200))
+		Expect(find(diff, vmwarev1.PriceItemStorage, vmwarev1.StorageTierTwoIopsGB).Delta).To(BeNumerically("~", -60))
+	})
+	It(`Diff the cost of a change with GetVcddPrice`, func() {
+		server := vmwarev1fake.NewServer(nil)
+		defer server.Close()
+		vmwareService, err := server.NewClient()
+		Expect(err).To(BeNil())
+
+		change := &vmwarev1.ClusterChange{HostCount: core.Int64Ptr(3), FileShares: vmwarev1.FileShareSizes{vmwarev1.StorageTierTwoIopsGB: 2000}}
+		diff, err := vmwareService.DiffClusterCost(context.Background(), "", cluster(), change)
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("GetVcddPrice")).To(Equal(2))
+		Expect(diff.Delta).To(BeNumerically("~", 3000+1000*0.12))
+
+		offline, err := estimator.DiffClusterCost(vmwarev1fake.DefaultCountry, cluster(), change)
+		Expect(err).To(BeNil())
+		Expect(offline.Items).To(Equal(diff.Items))
+	})
+	It(`Invoke DiffClusterCost with error: invalid change`, func() {
+		_, err := estimator.DiffClusterCost(vmwarev1fake.DefaultCountry, nil, &vmwarev1.ClusterChange{})
+		Expect(err).ToNot(BeNil())
+		_, err = estimator.DiffClusterCost(vmwarev1fake.DefaultCountry, cluster(), &vmwarev1.ClusterChange{HostCount: core.Int64Ptr(0)})
+		Expect(err).ToNot(BeNil())
+		_, err = estimator.DiffClusterCost(vmwarev1fake.DefaultCountry, cluster(), &vmwarev1.ClusterChange{FileShares: vmwarev1.FileShareSizes{"STORAGE_FUTURE_TIER": 1}})
+		Expect(err).ToNot(BeNil())
+		_, err = estimator.DiffClusterCost("", cluster(), &vmwarev1.ClusterChange{})
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke DiffPriceQuotes with error: quotes in different currencies`, func() {
+		quote := func(currency string) *vmwarev1.DirectorSitePriceQuoteResponse {
+			return &vmwarev1.DirectorSitePriceQuoteResponse{
+				Currency: core.StringPtr(currency),
+				Clusters: []vmwarev1.PriceInfoClusterCharge{{Price: core.Float64Ptr(1)}},
+			}
+		}
+		_, err := vmwarev1.DiffPriceQuotes(quote("USD"), quote("EUR"))
+		Expect(err).ToNot(BeNil())
+		_, err = vmwarev1.DiffPriceQuotes(quote("USD"), &vmwarev1.DirectorSitePriceQuoteResponse{Currency: core.StringPtr("USD")})
+		Expect(err).ToNot(BeNil())
+	})
+})
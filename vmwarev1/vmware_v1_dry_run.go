@@ -0,0 +1,144 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// DryRunOperations lists the operations that are planned instead of sent in dry-run mode.
+var DryRunOperations = []string{
+	"CreateWorkloadDomain",
+	"DeleteWorkloadDomain",
+	"SetHostsCount",
+	"SetFileShares",
+	"CreateVdc",
+	"DeleteVdc",
+	"ReplaceOrgAdminPassword",
+}
+
+// PlannedRequest : A request that an operation would send
+type PlannedRequest struct {
+	// The operationId, such as "SetHostsCount".
+	Operation string `json:"operation"`
+
+	// The HTTP method and the URL, with the path and query parameters of the request.
+	Method string `json:"method"`
+	URL    string `json:"url"`
+
+	// The headers of the request, with canonical names. The authentication header is added when a request is sent and
+	// is not included.
+	Header http.Header `json:"headers"`
+
+	// The JSON body of the request, uncompressed. Nil when the request has no body.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// DryRunError is returned by the operations of DryRunOperations in dry-run mode. The request was validated and built
+// but not sent. Use Plan to get the PlannedRequest of a call without handling this error.
+type DryRunError struct {
+	// The request that was not sent.
+	Request *PlannedRequest
+}
+
+// Error returns the error message.
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: %s %s %s was not sent", e.Request.Operation, e.Request.Method, e.Request.URL)
+}
+
+// dryRunKey is the context key that puts a single call in dry-run mode.
+type dryRunKey struct{}
+
+// WithDryRun returns a copy of ctx that puts the calls of the operations of DryRunOperations made with it in dry-run
+// mode, whether or not dry-run mode is enabled on the client.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// Plan : Build the request of a call of an operation of DryRunOperations without sending it
+// call is invoked with a copy of ctx from WithDryRun and must call an operation of DryRunOperations with it. Plan
+// returns the request that the operation would have sent, or the error of the call, such as a validation error. The
+// budget guard does not check planned calls, so planning sends no request at all.
+//
+//	planned, err := vmwarev1.Plan(ctx, func(ctx context.Context) error {
+//		_, _, err := vmwareService.SetHostsCountWithContext(ctx, setOptions)
+//		return err
+//	})
+func Plan(ctx context.Context, call func(ctx context.Context) error) (*PlannedRequest, error) {
+	err := call(WithDryRun(ctx))
+	var dryRunErr *DryRunError
+	if errors.As(err, &dryRunErr) {
+		return dryRunErr.Request, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("no operation of DryRunOperations was called")
+}
+
+// EnableDryRun : Plan instead of send the operations of DryRunOperations
+// In dry-run mode, these operations validate their options and build their request as usual, then return a
+// *DryRunError holding the PlannedRequest instead of sending it. Other operations are sent, so helpers that read the
+// state of resources keep working. The budget guard does not check the planned calls, as they are not sent.
+func (vmware *VmwareV1) EnableDryRun() {
+	vmware.dryRun = true
+}
+
+// DisableDryRun : Send every operation again
+// Calls made with a context from WithDryRun stay in dry-run mode.
+func (vmware *VmwareV1) DisableDryRun() {
+	vmware.dryRun = false
+}
+
+// dryRuns reports whether the request of the operation is planned instead of sent.
+func (vmware *VmwareV1) dryRuns(operation string, request *http.Request) bool {
+	if !vmware.dryRun && request.Context().Value(dryRunKey{}) == nil {
+		return false
+	}
+	for _, candidate := range DryRunOperations {
+		if candidate == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// planRequest returns the request as it would be sent, with its transaction ID.
+func (vmware *VmwareV1) planRequest(operation string, request *http.Request) (*PlannedRequest, error) {
+	vmware.setTransactionID(request)
+	body, err := requestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	planned := &PlannedRequest{
+		Operation: operation,
+		Method:    request.Method,
+		URL:       request.URL.String(),
+		Header:    make(http.Header),
+		Body:      body,
+	}
+	for name, values := range request.Header {
+		for _, value := range values {
+			planned.Header.Add(name, value)
+		}
+	}
+	return planned, nil
+}
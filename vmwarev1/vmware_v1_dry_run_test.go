@@ -0,0 +1,185 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 dry run`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		var err error
+		vmwareService, err = server.NewClient()
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	planned := func(err error) *vmwarev1.PlannedRequest {
+		var dryRunErr *vmwarev1.DryRunError
+		Expect(errors.As(err, &dryRunErr)).To(BeTrue(), "%v", err)
+		return dryRunErr.Request
+	}
+	newSiteOptions := func() *vmwarev1.CreateWorkloadDomainOptions {
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		return vmwareService.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster})
+	}
+
+	It(`Plan every mutating operation without sending it`, func() {
+		vmwareService.EnableDryRun()
+		vmwareService.EnableTransactionIDs(nil)
+		ctx := context.Background()
+		directorSite, err := vmwareService.NewNewVDCDirectorSite("testSite", &vmwarev1.VDCDirectorSiteCluster{ID: core.StringPtr("testCluster")})
+		Expect(err).To(BeNil())
+
+		calls := []struct {
+			operation string
+			method    string
+			path      string
+			call      func() error
+		}{
+			{"CreateWorkloadDomain", "POST", "/director_sites", func() error {
+				_, _, err := vmwareService.CreateWorkloadDomainWithContext(ctx, newSiteOptions())
+				return err
+			}},
+			{"DeleteWorkloadDomain", "DELETE", "/director_sites/testSite", func() error {
+				_, _, err := vmwareService.DeleteWorkloadDomainWithContext(ctx, vmwareService.NewDeleteWorkloadDomainOptions("testSite"))
+				return err
+			}},
+			{"SetHostsCount", "PUT", "/director_sites/testSite/clusters/testCluster/hosts_count", func() error {
+				_, _, err := vmwareService.SetHostsCountWithContext(ctx, vmwareService.NewSetHostsCountOptions("testSite", "testCluster", 4))
+				return err
+			}},
+			{"SetFileShares", "PUT", "/director_sites/testSite/clusters/testCluster/file_shares", func() error {
+				options := vmwareService.NewSetFileSharesOptions("testSite", "testCluster").SetFileShareSizes(vmwarev1.FileShareSizes{vmwarev1.StorageTierTwoIopsGB: 2000})
+				_, _, err := vmwareService.SetFileSharesWithContext(ctx, options)
+				return err
+			}},
+			{"CreateVdc", "POST", "/vdcs", func() error {
+				_, _, err := vmwareService.CreateVdcWithContext(ctx, vmwareService.NewCreateVdcOptions("vdc1", directorSite))
+				return err
+			}},
+			{"DeleteVdc", "DELETE", "/vdcs/testVdc", func() error {
+				_, _, err := vmwareService.DeleteVdcWithContext(ctx, vmwareService.NewDeleteVdcOptions("testVdc"))
+				return err
+			}},
+			{"ReplaceOrgAdminPassword", "PUT", "/director_site_password?site_id=testSite", func() error {
+				_, _, err := vmwareService.ReplaceOrgAdminPasswordWithContext(ctx, vmwareService.NewReplaceOrgAdminPasswordOptions("testSite"))
+				return err
+			}},
+		}
+		Expect(calls).To(HaveLen(len(vmwarev1.DryRunOperations)))
+		for _, call := range calls {
+			request := planned(call.call())
+			Expect(request.Operation).To(Equal(call.operation))
+			Expect(request.Method).To(Equal(call.method))
+			Expect(request.URL).To(Equal(server.URL + call.path))
+			Expect(request.Header.Get("X-Global-Transaction-ID")).ToNot(BeEmpty())
+			Expect(request.Header.Get("Authorization")).To(BeEmpty())
+			Expect(server.RequestCount(call.operation)).To(Equal(0))
+		}
+
+		// Operations that do not change anything are still sent.
+		_, _, err = vmwareService.ListWorkloadDomainInstances(vmwareService.NewListWorkloadDomainInstancesOptions())
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("ListWorkloadDomainInstances")).To(Equal(1))
+
+		vmwareService.DisableDryRun()
+		_, _, err = vmwareService.CreateWorkloadDomain(newSiteOptions())
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(1))
+	})
+	It(`Plan a single call with the exact JSON body`, func() {
+		vmwareService.SetEnableGzipCompression(true)
+		_, _, err := vmwareService.CreateWorkloadDomainWithContext(vmwarev1.WithDryRun(context.Background()), newSiteOptions())
+		request := planned(err)
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Header.Get("Content-Encoding")).To(Equal("gzip"))
+
+		var body map[string]interface{}
+		Expect(json.Unmarshal(request.Body, &body)).To(Succeed())
+		Expect(body["name"]).To(Equal("site1"))
+		Expect(body["resource_group"]).To(Equal("Default"))
+		Expect(body["clusters"]).To(HaveLen(1))
+		Expect(err.Error()).To(Equal("dry run: CreateWorkloadDomain POST " + server.URL + "/director_sites was not sent"))
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(0))
+
+		encoded, err := json.Marshal(request)
+		Expect(err).To(BeNil())
+		Expect(string(encoded)).To(ContainSubstring(`"body":{"clusters":[{`))
+
+		// The fake server does not accept compressed bodies.
+		vmwareService.SetEnableGzipCompression(false)
+		_, _, err = vmwareService.CreateWorkloadDomain(newSiteOptions())
+		Expect(err).To(BeNil())
+		Expect(server.RequestCount("CreateWorkloadDomain")).To(Equal(1))
+	})
+	It(`Return the planned request from Plan`, func() {
+		request, err := vmwarev1.Plan(context.Background(), func(ctx context.Context) error {
+			_, _, err := vmwareService.DeleteVdcWithContext(ctx, vmwareService.NewDeleteVdcOptions("testVdc"))
+			return err
+		})
+		Expect(err).To(BeNil())
+		Expect(request.Operation).To(Equal("DeleteVdc"))
+		Expect(request.URL).To(Equal(server.URL + "/vdcs/testVdc"))
+		Expect(server.RequestCount("DeleteVdc")).To(Equal(0))
+
+		_, err = vmwarev1.Plan(context.Background(), func(ctx context.Context) error {
+			_, _, err := vmwareService.DeleteVdcWithContext(ctx, vmwareService.NewDeleteVdcOptions(""))
+			return err
+		})
+		Expect(err).ToNot(BeNil())
+
+		_, err = vmwarev1.Plan(context.Background(), func(ctx context.Context) error {
+			_, _, err := vmwareService.ListVdcsWithContext(ctx, vmwareService.NewListVdcsOptions())
+			return err
+		})
+		Expect(err).To(MatchError("no operation of DryRunOperations was called"))
+	})
+	It(`Plan without the lookups of the budget guard`, func() {
+		Expect(vmwareService.EnableBudgetGuard(&vmwarev1.BudgetOptions{SiteLimit: 1000, AccountLimit: 1000})).To(Succeed())
+		vmwareService.EnableDryRun()
+
+		_, _, err := vmwareService.SetHostsCount(vmwareService.NewSetHostsCountOptions("testSite", "testCluster", 4))
+		Expect(planned(err).Operation).To(Equal("SetHostsCount"))
+		_, _, err = vmwareService.CreateWorkloadDomain(newSiteOptions())
+		Expect(planned(err).Operation).To(Equal("CreateWorkloadDomain"))
+		for _, operation := range []string{"GetSpecificWorkloadDomainInstance", "ListWorkloadDomainInstances", "GetVcddPrice"} {
+			Expect(server.RequestCount(operation)).To(Equal(0), operation)
+		}
+	})
+	It(`Validate the options before planning`, func() {
+		vmwareService.EnableDryRun()
+		_, _, err := vmwareService.SetHostsCount(vmwareService.NewSetHostsCountOptions("", "testCluster", 4))
+		Expect(err).ToNot(BeNil())
+		var dryRunErr *vmwarev1.DryRunError
+		Expect(errors.As(err, &dryRunErr)).To(BeFalse())
+	})
+})
@@ -0,0 +1,221 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// transactionIDHeader is the header that correlates a request with the service logs.
+const transactionIDHeader = "X-Global-Transaction-ID"
+
+// requestTransactionID returns the transaction ID header of the request. The operations set the header under its
+// non-canonical name, so it is looked up case-insensitively.
+func requestTransactionID(request *http.Request) string {
+	if transactionID := request.Header.Get(transactionIDHeader); transactionID != "" {
+		return transactionID
+	}
+	for name, values := range request.Header {
+		if strings.EqualFold(name, transactionIDHeader) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// APIError : An error response returned by the VMware as a Service API
+// Every VmwareV1 method returns an *APIError when the service answers with a non-2xx status. Use errors.As to access
+// it, or the IsNotFound, IsConflict, IsQuotaExceeded and IsRetryable helpers to classify it. Errors raised before a
+// response is received, such as validation or network errors, are returned unchanged.
+type APIError struct {
+	// The operationId of the method that failed, such as "CreateWorkloadDomain".
+	Operation string
+
+	// The HTTP status code of the response.
+	StatusCode int
+
+	// The errors decoded from the response body. Empty when the body is not a service error.
+	Errors []Error
+
+	// The trace ID of the response body, if any.
+	Trace string
+
+	// The X-Global-Transaction-ID of the response, or of the request when the response does not echo it.
+	TransactionID string
+
+	// The message of the error, taken from the first entry of Errors when present.
+	Message string
+
+	// The full response.
+	Response *core.DetailedResponse
+
+	// The error returned by the core service.
+	cause error
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: %s (status %d", e.Operation, e.Message, e.StatusCode)
+	if e.TransactionID != "" {
+		msg += fmt.Sprintf(", transaction ID %s", e.TransactionID)
+	}
+	return msg + ")"
+}
+
+// Unwrap returns the error returned by the core service.
+func (e *APIError) Unwrap() error {
+	return e.cause
+}
+
+// As finds the first decoded service error that matches target, so errors.As can reach every entry of Errors.
+func (e *APIError) As(target interface{}) bool {
+	return asServiceError(e.Errors, target)
+}
+
+// Is reports whether any decoded service error matches target, so errors.Is can reach every entry of Errors.
+func (e *APIError) Is(target error) bool {
+	return isServiceError(e.Errors, target)
+}
+
+// HasCode reports whether any of the decoded service errors has the code.
+func (e *APIError) HasCode(code string) bool {
+	for _, serviceErr := range e.Errors {
+		if core.StringNilMapper(serviceErr.Code) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPIError builds the *APIError of an error response. err is the error returned by the core service.
+func newAPIError(operation string, request *http.Request, response *core.DetailedResponse, err error) *APIError {
+	apiErr := &APIError{
+		Operation:     operation,
+		StatusCode:    response.StatusCode,
+		TransactionID: response.GetHeaders().Get(transactionIDHeader),
+		Message:       err.Error(),
+		Response:      response,
+		cause:         err,
+	}
+	if apiErr.TransactionID == "" && request != nil {
+		apiErr.TransactionID = requestTransactionID(request)
+	}
+
+	if result, ok := response.GetResultAsMap(); ok {
+		var body struct {
+			Errors []Error `json:"errors"`
+			Trace  string  `json:"trace"`
+		}
+		if buf, marshalErr := json.Marshal(result); marshalErr == nil && json.Unmarshal(buf, &body) == nil {
+			apiErr.Errors = body.Errors
+			apiErr.Trace = body.Trace
+		}
+	}
+	if len(apiErr.Errors) > 0 && apiErr.Errors[0].Message != nil {
+		apiErr.Message = *apiErr.Errors[0].Message
+	}
+	return apiErr
+}
+
+// Error returns the code, message and more_info of the service error as a single message.
+func (_error *Error) Error() string {
+	msg := fmt.Sprintf("%s: %s", core.StringNilMapper(_error.Code), core.StringNilMapper(_error.Message))
+	if _error.MoreInfo != nil {
+		msg += fmt.Sprintf(" (%s)", *_error.MoreInfo)
+	}
+	return msg
+}
+
+// Is reports whether target is an *Error with the same code, so errors.Is(err, &Error{Code: core.StringPtr(code)})
+// finds a service error by its code.
+func (_error *Error) Is(target error) bool {
+	targetErr, ok := target.(*Error)
+	return ok && targetErr.Code != nil && _error.Code != nil && *targetErr.Code == *_error.Code
+}
+
+// asServiceError finds the first of the service errors that matches target, as errors.As does for a single error.
+func asServiceError(serviceErrs []Error, target interface{}) bool {
+	for i := range serviceErrs {
+		if errors.As(&serviceErrs[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isServiceError reports whether any of the service errors matches target, as errors.Is does for a single error.
+func isServiceError(serviceErrs []Error, target error) bool {
+	for i := range serviceErrs {
+		if errors.Is(&serviceErrs[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an *APIError with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an *APIError with status 409, which the service returns when the resource is
+// busy with another operation or in a status that does not allow the request.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsQuotaExceeded reports whether err is an *APIError caused by an exhausted account quota, identified by a service
+// error code that mentions "quota".
+func IsQuotaExceeded(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, serviceErr := range apiErr.Errors {
+		if strings.Contains(strings.ToLower(core.StringNilMapper(serviceErr.Code)), "quota") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable reports whether err is an *APIError that is worth retrying unchanged: status 429 (unless the quota is
+// exceeded), 500, 502, 503 or 504.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return !IsQuotaExceeded(err)
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
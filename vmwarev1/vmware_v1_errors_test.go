@@ -0,0 +1,150 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 API errors`, func() {
+	var testServer *httptest.Server
+	var statusCode int
+	var body string
+
+	BeforeEach(func() {
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			res.Header().Set("Content-type", "application/json")
+			res.Header().Set("X-Global-Transaction-ID", "testTransaction")
+			res.WriteHeader(statusCode)
+			fmt.Fprint(res, body)
+		}))
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+	getVdc := func() error {
+		vmwareService, serviceErr := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		_, _, err := vmwareService.GetVdc(vmwareService.NewGetVdcOptions("testString"))
+		return err
+	}
+
+	It(`Decode the service errors of a 404 response`, func() {
+		statusCode = 404
+		body = `{"errors": [{"code": "vdc_not_found", "message": "The VDC was not found.", "more_info": "https://cloud.ibm.com/docs"}], "trace": "testTrace"}`
+
+		err := getVdc()
+		var apiErr *vmwarev1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Operation).To(Equal("GetVdc"))
+		Expect(apiErr.StatusCode).To(Equal(404))
+		Expect(apiErr.TransactionID).To(Equal("testTransaction"))
+		Expect(apiErr.Trace).To(Equal("testTrace"))
+		Expect(apiErr.Message).To(Equal("The VDC was not found."))
+		Expect(apiErr.Errors).To(HaveLen(1))
+		Expect(*apiErr.Errors[0].MoreInfo).To(Equal("https://cloud.ibm.com/docs"))
+		Expect(apiErr.HasCode("vdc_not_found")).To(BeTrue())
+		Expect(apiErr.Response.StatusCode).To(Equal(404))
+		Expect(err.Error()).To(Equal("GetVdc: The VDC was not found. (status 404, transaction ID testTransaction)"))
+
+		var serviceErr *vmwarev1.Error
+		Expect(errors.As(err, &serviceErr)).To(BeTrue())
+		Expect(*serviceErr.Code).To(Equal("vdc_not_found"))
+
+		Expect(vmwarev1.IsNotFound(err)).To(BeTrue())
+		Expect(vmwarev1.IsConflict(err)).To(BeFalse())
+		Expect(vmwarev1.IsRetryable(err)).To(BeFalse())
+	})
+	It(`Reach every service error and keep the core error as the cause`, func() {
+		statusCode = 400
+		body = `{"errors": [{"code": "invalid_name", "message": "The name is invalid."}, {"code": "invalid_edge", "message": "The edge is invalid."}]}`
+
+		err := getVdc()
+		var apiErr *vmwarev1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		var serviceErr *vmwarev1.Error
+		Expect(errors.As(err, &serviceErr)).To(BeTrue())
+		Expect(*serviceErr.Code).To(Equal("invalid_name"))
+		Expect(errors.Is(err, &vmwarev1.Error{Code: core.StringPtr("invalid_edge")})).To(BeTrue())
+		Expect(errors.Is(err, &vmwarev1.Error{Code: core.StringPtr("vdc_not_found")})).To(BeFalse())
+
+		cause := errors.Unwrap(apiErr)
+		Expect(cause).ToNot(BeNil())
+		Expect(cause.Error()).To(Equal("The name is invalid."))
+	})
+	It(`Classify conflicts`, func() {
+		statusCode = 409
+		body = `{"errors": [{"code": "vdc_busy", "message": "The VDC is busy."}]}`
+
+		err := getVdc()
+		Expect(vmwarev1.IsConflict(err)).To(BeTrue())
+		Expect(vmwarev1.IsNotFound(err)).To(BeFalse())
+	})
+	It(`Classify exceeded quotas as not retryable`, func() {
+		statusCode = 429
+		body = `{"errors": [{"code": "quota_exceeded", "message": "The account quota is exceeded."}]}`
+
+		err := getVdc()
+		Expect(vmwarev1.IsQuotaExceeded(err)).To(BeTrue())
+		Expect(vmwarev1.IsRetryable(err)).To(BeFalse())
+	})
+	It(`Classify rate limiting and server errors as retryable`, func() {
+		for _, code := range []int{429, 500, 502, 503, 504} {
+			statusCode = code
+			body = `{"errors": [{"code": "unavailable", "message": "Try again later."}]}`
+
+			err := getVdc()
+			Expect(vmwarev1.IsRetryable(err)).To(BeTrue())
+			Expect(vmwarev1.IsQuotaExceeded(err)).To(BeFalse())
+		}
+	})
+	It(`Keep the status when the body is not a service error`, func() {
+		statusCode = 500
+		body = ``
+
+		err := getVdc()
+		var apiErr *vmwarev1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Errors).To(BeEmpty())
+		Expect(apiErr.Message).To(Equal("Internal Server Error"))
+		Expect(vmwarev1.IsRetryable(err)).To(BeTrue())
+	})
+	It(`Return validation errors unchanged`, func() {
+		var apiErr *vmwarev1.APIError
+		vmwareService, _ := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		_, _, err := vmwareService.GetVdc(nil)
+		Expect(err).ToNot(BeNil())
+		Expect(errors.As(err, &apiErr)).To(BeFalse())
+		Expect(vmwarev1.IsRetryable(nil)).To(BeFalse())
+	})
+})
@@ -0,0 +1,233 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// BaseChargeMetric is the price catalog metric of the director site base charge.
+const BaseChargeMetric = "DIRECTOR_SITE"
+
+// Storage tiers of FileShares. They are both the JSON keys of FileShares and the price catalog metrics of the tiers.
+const (
+	StorageTierPointTwoFiveIopsGB = "STORAGE_POINT_TWO_FIVE_IOPS_GB"
+	StorageTierTwoIopsGB          = "STORAGE_TWO_IOPS_GB"
+	StorageTierFourIopsGB         = "STORAGE_FOUR_IOPS_GB"
+	StorageTierTenIopsGB          = "STORAGE_TEN_IOPS_GB"
+)
+
+// Names of the items of each PriceInfoClusterCharge computed by PriceEstimator.
+const (
+	PriceItemHosts   = "hosts"
+	PriceItemStorage = "storage"
+)
+
+// fileShareTier : The size of one storage tier of a FileShares.
+type fileShareTier struct {
+	tier string
+	size *int64
+}
+
+// fileShareTiers returns the storage tiers of fileShares in catalog order.
+func fileShareTiers(fileShares *FileShares) []fileShareTier {
+	if fileShares == nil {
+		return nil
+	}
+	return []fileShareTier{
+		{StorageTierPointTwoFiveIopsGB, fileShares.STORAGEPOINTTWOFIVEIOPSGB},
+		{StorageTierTwoIopsGB, fileShares.STORAGETWOIOPSGB},
+		{StorageTierFourIopsGB, fileShares.STORAGEFOURIOPSGB},
+		{StorageTierTenIopsGB, fileShares.STORAGETENIOPSGB},
+	}
+}
+
+// MissingPriceError is returned by PriceEstimator when the catalog has no price for a metric in a country.
+type MissingPriceError struct {
+	// The metric without a price.
+	Metric string
+
+	// The country of the estimate.
+	Country string
+}
+
+// Error returns the error message.
+func (e *MissingPriceError) Error() string {
+	return fmt.Sprintf("the price catalog has no price for metric %s in country %s", e.Metric, e.Country)
+}
+
+// PriceEstimator : Estimate director site prices offline from the price catalog returned by ListPrices
+// An estimate has the same shape as the DirectorSitePriceQuoteResponse of GetVcddPrice: a base charge, and for each
+// cluster a "hosts" item with the host profile as sub-item and a "storage" item with one sub-item per storage tier.
+//
+// Prices are tiered by DirectorSitePriceItem.QuantityTier: a metric is charged, for its whole quantity, at the price
+// of the highest quantity tier that does not exceed the quantity. A price without a quantity tier applies from 1 on.
+// Hosts are counted per cluster and storage in GB per cluster.
+type PriceEstimator struct {
+	// prices holds, for each metric and country, the price list sorted by quantity tier.
+	prices map[string]map[string]DirectorSitePriceListItem
+}
+
+// NewPriceEstimator : Construct a PriceEstimator from a price catalog
+func NewPriceEstimator(pricing *DirectorSitePricingInfo) (estimator *PriceEstimator, err error) {
+	err = core.ValidateNotNil(pricing, "pricing cannot be nil")
+	if err != nil {
+		return
+	}
+	estimator = &PriceEstimator{prices: make(map[string]map[string]DirectorSitePriceListItem)}
+	for _, metric := range pricing.DirectorSitePricing {
+		name := core.StringNilMapper(metric.Metric)
+		if estimator.prices[name] == nil {
+			estimator.prices[name] = make(map[string]DirectorSitePriceListItem)
+		}
+		for _, item := range metric.PriceList {
+			prices := make([]DirectorSitePriceItem, 0, len(item.Prices))
+			for _, price := range item.Prices {
+				if price.Price != nil {
+					prices = append(prices, price)
+				}
+			}
+			sort.SliceStable(prices, func(i, j int) bool {
+				return quantityTier(prices[i]) < quantityTier(prices[j])
+			})
+			item.Prices = prices
+			estimator.prices[name][core.StringNilMapper(item.Country)] = item
+		}
+	}
+	return
+}
+
+// EstimatePrice : Estimate the price of a director site offline
+// EstimatePrice is a shortcut for NewPriceEstimator followed by PriceEstimator.Estimate. Construct a PriceEstimator
+// once instead when estimating many configurations against the same catalog.
+func EstimatePrice(pricing *DirectorSitePricingInfo, country string, clusters []DirectorSitePriceQuoteClusterInfo) (*DirectorSitePriceQuoteResponse, error) {
+	estimator, err := NewPriceEstimator(pricing)
+	if err != nil {
+		return nil, err
+	}
+	return estimator.Estimate(country, clusters)
+}
+
+// Estimate : Estimate the price of a director site with the clusters in the country
+// A *MissingPriceError is returned when the catalog has no price for the base charge, a host profile or a storage
+// tier used by the clusters.
+func (estimator *PriceEstimator) Estimate(country string, clusters []DirectorSitePriceQuoteClusterInfo) (quote *DirectorSitePriceQuoteResponse, err error) {
+	if country == "" {
+		err = fmt.Errorf("country cannot be empty")
+		return
+	}
+
+	basePrice, currency, err := estimator.UnitPrice(BaseChargeMetric, country, 1)
+	if err != nil {
+		return
+	}
+	total := basePrice
+	quote = &DirectorSitePriceQuoteResponse{
+		BaseCharge: &PriceInfoBaseCharge{
+			Name:     core.StringPtr(BaseChargeMetric),
+			Currency: core.StringPtr(currency),
+			Price:    core.Float64Ptr(basePrice),
+		},
+		Currency: core.StringPtr(currency),
+	}
+
+	for _, cluster := range clusters {
+		hostCount := int64(0)
+		if cluster.HostCount != nil {
+			hostCount = *cluster.HostCount
+		}
+		hostPrice, _, err := estimator.UnitPrice(core.StringNilMapper(cluster.HostProfile), country, hostCount)
+		if err != nil {
+			return nil, err
+		}
+		hosts := PriceInfoClusterItem{
+			Name:     core.StringPtr(PriceItemHosts),
+			Currency: core.StringPtr(currency),
+			Price:    core.Float64Ptr(hostPrice * float64(hostCount)),
+			Items: []PriceInfoClusterSubItem{
+				{
+					Name:     cluster.HostProfile,
+					Count:    core.Int64Ptr(hostCount),
+					Currency: core.StringPtr(currency),
+					Price:    core.Float64Ptr(hostPrice),
+				},
+			},
+		}
+
+		storage := PriceInfoClusterItem{
+			Name:     core.StringPtr(PriceItemStorage),
+			Currency: core.StringPtr(currency),
+			Price:    core.Float64Ptr(0),
+		}
+		for _, share := range fileShareTiers(cluster.FileShares) {
+			if share.size == nil || *share.size == 0 {
+				continue
+			}
+			storagePrice, _, err := estimator.UnitPrice(share.tier, country, *share.size)
+			if err != nil {
+				return nil, err
+			}
+			*storage.Price += storagePrice * float64(*share.size)
+			storage.Items = append(storage.Items, PriceInfoClusterSubItem{
+				Name:     core.StringPtr(share.tier),
+				Count:    core.Int64Ptr(*share.size),
+				Currency: core.StringPtr(currency),
+				Price:    core.Float64Ptr(storagePrice),
+			})
+		}
+
+		clusterPrice := *hosts.Price + *storage.Price
+		total += clusterPrice
+		quote.Clusters = append(quote.Clusters, PriceInfoClusterCharge{
+			Name:     cluster.Name,
+			Currency: core.StringPtr(currency),
+			Price:    core.Float64Ptr(clusterPrice),
+			Items:    []PriceInfoClusterItem{hosts, storage},
+		})
+	}
+	quote.Total = core.Float64Ptr(total)
+	return
+}
+
+// UnitPrice : The price of a single unit of the metric in the country when quantity units are charged
+// The currency of the price is returned with it.
+func (estimator *PriceEstimator) UnitPrice(metric string, country string, quantity int64) (price float64, currency string, err error) {
+	item, ok := estimator.prices[metric][country]
+	if !ok || len(item.Prices) == 0 {
+		err = &MissingPriceError{Metric: metric, Country: country}
+		return
+	}
+	currency = core.StringNilMapper(item.Currency)
+	price = *item.Prices[0].Price
+	for _, tier := range item.Prices[1:] {
+		if quantityTier(tier) > quantity {
+			break
+		}
+		price = *tier.Price
+	}
+	return
+}
+
+func quantityTier(price DirectorSitePriceItem) int64 {
+	if price.QuantityTier == nil {
+		return 1
+	}
+	return *price.QuantityTier
+}
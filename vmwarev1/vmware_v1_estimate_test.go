@@ -0,0 +1,120 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"errors"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 price estimator`, func() {
+	priceList := func(country string, currency string, prices ...vmwarev1.DirectorSitePriceItem) vmwarev1.DirectorSitePriceListItem {
+		return vmwarev1.DirectorSitePriceListItem{Country: core.StringPtr(country), Currency: core.StringPtr(currency), Prices: prices}
+	}
+	tier := func(price float64, quantityTier int64) vmwarev1.DirectorSitePriceItem {
+		return vmwarev1.DirectorSitePriceItem{Price: core.Float64Ptr(price), QuantityTier: core.Int64Ptr(quantityTier)}
+	}
+	pricing := &vmwarev1.DirectorSitePricingInfo{
+		DirectorSitePricing: []vmwarev1.DirectorSitePriceMetric{
+			{
+				Metric:    core.StringPtr(vmwarev1.BaseChargeMetric),
+				PriceList: []vmwarev1.DirectorSitePriceListItem{priceList("USA", "USD", tier(1000, 1)), priceList("DEU", "EUR", tier(900, 1))},
+			},
+			{
+				// Tiers are deliberately out of order.
+				Metric:    core.StringPtr("testProfile"),
+				PriceList: []vmwarev1.DirectorSitePriceListItem{priceList("USA", "USD", tier(80, 10), tier(100, 1), tier(90, 4))},
+			},
+			{
+				Metric:    core.StringPtr(vmwarev1.StorageTierTwoIopsGB),
+				PriceList: []vmwarev1.DirectorSitePriceListItem{priceList("USA", "USD", vmwarev1.DirectorSitePriceItem{Price: core.Float64Ptr(0.5)})},
+			},
+		},
+	}
+	cluster := func(hostCount int64, storage int64) vmwarev1.DirectorSitePriceQuoteClusterInfo {
+		return vmwarev1.DirectorSitePriceQuoteClusterInfo{
+			Name:        core.StringPtr("testCluster"),
+			HostProfile: core.StringPtr("testProfile"),
+			HostCount:   core.Int64Ptr(hostCount),
+			FileShares:  &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(storage)},
+		}
+	}
+
+	It(`Estimate a director site with the price breakdown of GetVcddPrice`, func() {
+		quote, err := vmwarev1.EstimatePrice(pricing, "USA", []vmwarev1.DirectorSitePriceQuoteClusterInfo{cluster(2, 100)})
+		Expect(err).To(BeNil())
+		Expect(*quote.Currency).To(Equal("USD"))
+		Expect(*quote.BaseCharge.Name).To(Equal(vmwarev1.BaseChargeMetric))
+		Expect(*quote.BaseCharge.Price).To(Equal(1000.0))
+		Expect(quote.Clusters).To(HaveLen(1))
+
+		charge := quote.Clusters[0]
+		Expect(*charge.Name).To(Equal("testCluster"))
+		Expect(*charge.Price).To(Equal(250.0))
+		Expect(charge.Items).To(HaveLen(2))
+		Expect(*charge.Items[0].Name).To(Equal(vmwarev1.PriceItemHosts))
+		Expect(*charge.Items[0].Price).To(Equal(200.0))
+		Expect(*charge.Items[0].Items[0].Name).To(Equal("testProfile"))
+		Expect(*charge.Items[0].Items[0].Count).To(Equal(int64(2)))
+		Expect(*charge.Items[0].Items[0].Price).To(Equal(100.0))
+		Expect(*charge.Items[1].Name).To(Equal(vmwarev1.PriceItemStorage))
+		Expect(*charge.Items[1].Price).To(Equal(50.0))
+		Expect(*charge.Items[1].Items[0].Name).To(Equal(vmwarev1.StorageTierTwoIopsGB))
+		Expect(*charge.Items[1].Items[0].Count).To(Equal(int64(100)))
+		Expect(*quote.Total).To(Equal(1250.0))
+	})
+	It(`Charge the whole quantity at the price of the highest tier reached`, func() {
+		estimator, err := vmwarev1.NewPriceEstimator(pricing)
+		Expect(err).To(BeNil())
+		for quantity, expected := range map[int64]float64{0: 100, 1: 100, 3: 100, 4: 90, 9: 90, 10: 80, 40: 80} {
+			price, currency, err := estimator.UnitPrice("testProfile", "USA", quantity)
+			Expect(err).To(BeNil())
+			Expect(currency).To(Equal("USD"))
+			Expect(price).To(Equal(expected), "quantity %d", quantity)
+		}
+
+		quote, err := estimator.Estimate("USA", []vmwarev1.DirectorSitePriceQuoteClusterInfo{cluster(4, 0), cluster(10, 0)})
+		Expect(err).To(BeNil())
+		Expect(*quote.Clusters[0].Price).To(Equal(360.0))
+		Expect(quote.Clusters[0].Items[1].Items).To(BeEmpty())
+		Expect(*quote.Clusters[1].Price).To(Equal(800.0))
+		Expect(*quote.Total).To(Equal(2160.0))
+	})
+	It(`Estimate the base charge alone without clusters`, func() {
+		quote, err := vmwarev1.EstimatePrice(pricing, "DEU", nil)
+		Expect(err).To(BeNil())
+		Expect(*quote.Currency).To(Equal("EUR"))
+		Expect(*quote.Total).To(Equal(900.0))
+	})
+	It(`Report the metric without a price`, func() {
+		_, err := vmwarev1.EstimatePrice(pricing, "DEU", []vmwarev1.DirectorSitePriceQuoteClusterInfo{cluster(2, 0)})
+		var missingErr *vmwarev1.MissingPriceError
+		Expect(errors.As(err, &missingErr)).To(BeTrue())
+		Expect(missingErr.Metric).To(Equal("testProfile"))
+		Expect(missingErr.Country).To(Equal("DEU"))
+	})
+	It(`Invoke EstimatePrice with error: invalid parameters`, func() {
+		_, err := vmwarev1.EstimatePrice(nil, "USA", nil)
+		Expect(err).ToNot(BeNil())
+		_, err = vmwarev1.EstimatePrice(pricing, "", nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
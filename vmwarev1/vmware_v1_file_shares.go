@@ -0,0 +1,222 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// StorageTiers lists the storage tiers of FileShares in catalog order.
+var StorageTiers = []string{StorageTierPointTwoFiveIopsGB, StorageTierTwoIopsGB, StorageTierFourIopsGB, StorageTierTenIopsGB}
+
+// FileShareSizes : The size in GB of file shares, keyed by storage tier
+// The keys are the JSON keys of FileShares, such as StorageTierTwoIopsGB. Unlike FileShares, FileShareSizes keeps the
+// storage tiers unknown to this version of the SDK, so the file shares of a cluster are never silently dropped. Use
+// FileShares to build orders for CreateWorkloadDomain and GetVcddPrice, and SetFileSharesOptions.SetFileShareSizes
+// to call SetFileShares.
+type FileShareSizes map[string]int64
+
+// NewFileShareSizes returns the sizes of the storage tiers set in fileShares.
+func NewFileShareSizes(fileShares *FileShares) FileShareSizes {
+	sizes := make(FileShareSizes)
+	for _, share := range fileShareTiers(fileShares) {
+		if share.size != nil {
+			sizes[share.tier] = *share.size
+		}
+	}
+	return sizes
+}
+
+// ParseFileShareSizes : Decode the untyped file shares of Cluster and ClusterSummary
+// Sizes may be any Go integer or float type or json.Number, as long as they are whole numbers of GB. Tiers with a
+// nil size are skipped.
+func ParseFileShareSizes(fileShares map[string]interface{}) (FileShareSizes, error) {
+	sizes := make(FileShareSizes, len(fileShares))
+	for tier, value := range fileShares {
+		if value == nil {
+			continue
+		}
+		size, err := fileShareSize(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %v of storage tier %s: %s", value, tier, err.Error())
+		}
+		sizes[tier] = size
+	}
+	return sizes, nil
+}
+
+// fileShareSize converts a decoded JSON number to a whole number of GB.
+func fileShareSize(value interface{}) (int64, error) {
+	var size float64
+	switch value := value.(type) {
+	case float64:
+		size = value
+	case float32:
+		size = float64(value)
+	case int:
+		return int64(value), nil
+	case int32:
+		return int64(value), nil
+	case int64:
+		return value, nil
+	case json.Number:
+		if size, err := value.Int64(); err == nil {
+			return size, nil
+		}
+		parsed, err := value.Float64()
+		if err != nil {
+			return 0, err
+		}
+		size = parsed
+	default:
+		return 0, fmt.Errorf("not a number")
+	}
+	if size != math.Trunc(size) || math.IsInf(size, 0) || size >= math.MaxInt64 || size < math.MinInt64 {
+		return 0, fmt.Errorf("not a whole number")
+	}
+	return int64(size), nil
+}
+
+// FileShareSizes returns the file shares of the cluster.
+func (cluster *Cluster) FileShareSizes() (FileShareSizes, error) {
+	return ParseFileShareSizes(cluster.FileShares)
+}
+
+// FileShareSizes returns the file shares of the cluster.
+func (summary *ClusterSummary) FileShareSizes() (FileShareSizes, error) {
+	return ParseFileShareSizes(summary.FileShares)
+}
+
+// FileShares returns the sizes of the storage tiers known to FileShares. Use Unknown to find the tiers it leaves out.
+func (sizes FileShareSizes) FileShares() *FileShares {
+	fileShares := &FileShares{}
+	for tier, size := range sizes {
+		switch tier {
+		case StorageTierPointTwoFiveIopsGB:
+			fileShares.STORAGEPOINTTWOFIVEIOPSGB = core.Int64Ptr(size)
+		case StorageTierTwoIopsGB:
+			fileShares.STORAGETWOIOPSGB = core.Int64Ptr(size)
+		case StorageTierFourIopsGB:
+			fileShares.STORAGEFOURIOPSGB = core.Int64Ptr(size)
+		case StorageTierTenIopsGB:
+			fileShares.STORAGETENIOPSGB = core.Int64Ptr(size)
+		}
+	}
+	return fileShares
+}
+
+// Unknown returns the sizes of the storage tiers that are not in StorageTiers.
+func (sizes FileShareSizes) Unknown() FileShareSizes {
+	unknown := make(FileShareSizes)
+	for tier, size := range sizes {
+		if !isStorageTier(tier) {
+			unknown[tier] = size
+		}
+	}
+	return unknown
+}
+
+// Tiers returns the storage tiers of sizes, the tiers of StorageTiers first in catalog order, then the unknown ones
+// sorted by name.
+func (sizes FileShareSizes) Tiers() []string {
+	tiers := make([]string, 0, len(sizes))
+	for _, tier := range StorageTiers {
+		if _, ok := sizes[tier]; ok {
+			tiers = append(tiers, tier)
+		}
+	}
+	unknown := make([]string, 0, len(sizes)-len(tiers))
+	for tier := range sizes {
+		if !isStorageTier(tier) {
+			unknown = append(unknown, tier)
+		}
+	}
+	sort.Strings(unknown)
+	return append(tiers, unknown...)
+}
+
+// Total returns the total size in GB of every storage tier.
+func (sizes FileShareSizes) Total() int64 {
+	total := int64(0)
+	for _, size := range sizes {
+		total += size
+	}
+	return total
+}
+
+// Add returns the sum of the sizes of each storage tier.
+func (sizes FileShareSizes) Add(other FileShareSizes) FileShareSizes {
+	sum := make(FileShareSizes, len(sizes))
+	for tier, size := range sizes {
+		sum[tier] = size
+	}
+	for tier, size := range other {
+		sum[tier] += size
+	}
+	return sum
+}
+
+// Subtract returns the sizes minus the sizes of other for each storage tier. Sizes may become negative.
+func (sizes FileShareSizes) Subtract(other FileShareSizes) FileShareSizes {
+	negated := make(FileShareSizes, len(other))
+	for tier, size := range other {
+		negated[tier] = -size
+	}
+	return sizes.Add(negated)
+}
+
+// Diff returns the change of each storage tier from sizes to target, keeping only the tiers that change. A tier that
+// is missing counts as 0 GB.
+func (sizes FileShareSizes) Diff(target FileShareSizes) FileShareSizes {
+	diff := target.Subtract(sizes)
+	for tier, change := range diff {
+		if change == 0 {
+			delete(diff, tier)
+		}
+	}
+	return diff
+}
+
+// Equal reports whether both have the same size for every storage tier, a missing tier counting as 0 GB.
+func (sizes FileShareSizes) Equal(other FileShareSizes) bool {
+	return len(sizes.Diff(other)) == 0
+}
+
+// SetFileShareSizes : Allow user to set the storage tiers from FileShareSizes
+// Only the tiers known to FileShares can be set; the others are ignored.
+func (_options *SetFileSharesOptions) SetFileShareSizes(sizes FileShareSizes) *SetFileSharesOptions {
+	fileShares := sizes.FileShares()
+	_options.STORAGEPOINTTWOFIVEIOPSGB = fileShares.STORAGEPOINTTWOFIVEIOPSGB
+	_options.STORAGETWOIOPSGB = fileShares.STORAGETWOIOPSGB
+	_options.STORAGEFOURIOPSGB = fileShares.STORAGEFOURIOPSGB
+	_options.STORAGETENIOPSGB = fileShares.STORAGETENIOPSGB
+	return _options
+}
+
+func isStorageTier(tier string) bool {
+	for _, known := range StorageTiers {
+		if tier == known {
+			return true
+		}
+	}
+	return false
+}
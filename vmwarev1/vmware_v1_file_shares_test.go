@@ -0,0 +1,123 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 file share sizes`, func() {
+	It(`Decode the file shares of a cluster and keep unknown tiers`, func() {
+		var raw map[string]json.RawMessage
+		Expect(json.Unmarshal([]byte(`{"file_shares": {"STORAGE_TWO_IOPS_GB": 24000, "STORAGE_FOUR_IOPS_GB": 0, "STORAGE_FORTY_IOPS_GB": 100}}`), &raw)).To(Succeed())
+		var cluster *vmwarev1.Cluster
+		Expect(vmwarev1.UnmarshalCluster(raw, &cluster)).To(Succeed())
+
+		sizes, err := cluster.FileShareSizes()
+		Expect(err).To(BeNil())
+		Expect(sizes).To(Equal(vmwarev1.FileShareSizes{
+			vmwarev1.StorageTierTwoIopsGB:  24000,
+			vmwarev1.StorageTierFourIopsGB: 0,
+			"STORAGE_FORTY_IOPS_GB":        100,
+		}))
+		Expect(sizes.Unknown()).To(Equal(vmwarev1.FileShareSizes{"STORAGE_FORTY_IOPS_GB": 100}))
+		Expect(sizes.Tiers()).To(Equal([]string{vmwarev1.StorageTierTwoIopsGB, vmwarev1.StorageTierFourIopsGB, "STORAGE_FORTY_IOPS_GB"}))
+		Expect(sizes.FileShares()).To(Equal(&vmwarev1.FileShares{
+			STORAGETWOIOPSGB:  core.Int64Ptr(24000),
+			STORAGEFOURIOPSGB: core.Int64Ptr(0),
+		}))
+
+		summary := vmwarev1.ClusterSummary{FileShares: map[string]interface{}{vmwarev1.StorageTierTenIopsGB: json.Number("5")}}
+		sizes, err = summary.FileShareSizes()
+		Expect(err).To(BeNil())
+		Expect(sizes).To(Equal(vmwarev1.FileShareSizes{vmwarev1.StorageTierTenIopsGB: 5}))
+	})
+	It(`Invoke ParseFileShareSizes with error: invalid sizes`, func() {
+		_, err := vmwarev1.ParseFileShareSizes(map[string]interface{}{vmwarev1.StorageTierTwoIopsGB: 1.5})
+		Expect(err).ToNot(BeNil())
+		_, err = vmwarev1.ParseFileShareSizes(map[string]interface{}{vmwarev1.StorageTierTwoIopsGB: "many"})
+		Expect(err).ToNot(BeNil())
+		sizes, err := vmwarev1.ParseFileShareSizes(map[string]interface{}{vmwarev1.StorageTierTwoIopsGB: nil, vmwarev1.StorageTierTenIopsGB: 7})
+		Expect(err).To(BeNil())
+		Expect(sizes).To(Equal(vmwarev1.FileShareSizes{vmwarev1.StorageTierTenIopsGB: 7}))
+	})
+	It(`Compute totals, sums and differences`, func() {
+		ordered := vmwarev1.NewFileShareSizes(&vmwarev1.FileShares{
+			STORAGETWOIOPSGB: core.Int64Ptr(1000),
+			STORAGETENIOPSGB: core.Int64Ptr(500),
+		})
+		actual := vmwarev1.FileShareSizes{vmwarev1.StorageTierTwoIopsGB: 1000, vmwarev1.StorageTierFourIopsGB: 200}
+
+		Expect(ordered.Total()).To(Equal(int64(1500)))
+		Expect(ordered.Add(actual)).To(Equal(vmwarev1.FileShareSizes{
+			vmwarev1.StorageTierTwoIopsGB:  2000,
+			vmwarev1.StorageTierFourIopsGB: 200,
+			vmwarev1.StorageTierTenIopsGB:  500,
+		}))
+		Expect(ordered.Subtract(actual)).To(Equal(vmwarev1.FileShareSizes{
+			vmwarev1.StorageTierTwoIopsGB:  0,
+			vmwarev1.StorageTierFourIopsGB: -200,
+			vmwarev1.StorageTierTenIopsGB:  500,
+		}))
+		Expect(actual.Diff(ordered)).To(Equal(vmwarev1.FileShareSizes{
+			vmwarev1.StorageTierFourIopsGB: -200,
+			vmwarev1.StorageTierTenIopsGB:  500,
+		}))
+		Expect(ordered.Equal(ordered.Add(vmwarev1.FileShareSizes{vmwarev1.StorageTierFourIopsGB: 0}))).To(BeTrue())
+		Expect(ordered.Equal(actual)).To(BeFalse())
+
+		// The operands are not modified.
+		Expect(actual).To(HaveLen(2))
+		Expect(ordered).To(HaveLen(2))
+	})
+	It(`Set file shares from sizes`, func() {
+		server := vmwarev1fake.NewServer(nil)
+		defer server.Close()
+		vmwareService, err := server.NewClient()
+		Expect(err).To(BeNil())
+
+		ordered := vmwarev1.FileShareSizes{vmwarev1.StorageTierTwoIopsGB: 1000}
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, ordered.FileShares(), vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		site, _, err := vmwareService.CreateWorkloadDomain(vmwareService.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+		Expect(err).To(BeNil())
+		actual, err := site.Clusters[0].FileShareSizes()
+		Expect(err).To(BeNil())
+		Expect(actual.Equal(ordered)).To(BeTrue())
+
+		target := ordered.Add(vmwarev1.FileShareSizes{vmwarev1.StorageTierFourIopsGB: 500})
+		options := vmwareService.NewSetFileSharesOptions(*site.ID, *site.Clusters[0].ID).SetFileShareSizes(target)
+		Expect(options.STORAGETWOIOPSGB).To(Equal(core.Int64Ptr(1000)))
+		Expect(options.STORAGEFOURIOPSGB).To(Equal(core.Int64Ptr(500)))
+		Expect(options.STORAGETENIOPSGB).To(BeNil())
+		_, _, err = vmwareService.SetFileShares(options)
+		Expect(err).To(BeNil())
+
+		server.CompletePending()
+		updated, _, err := vmwareService.GetSpecificClusterInstance(vmwareService.NewGetSpecificClusterInstanceOptions(*site.ID, *site.Clusters[0].ID))
+		Expect(err).To(BeNil())
+		actual, err = updated.FileShareSizes()
+		Expect(err).To(BeNil())
+		Expect(actual.Diff(target)).To(BeEmpty())
+	})
+})
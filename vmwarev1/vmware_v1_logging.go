@@ -0,0 +1,158 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Logger : A structured logger for the requests sent by VmwareV1
+// The methods have the signatures of the methods of *slog.Logger, so a *slog.Logger can be used as is. args are
+// alternating keys and values.
+type Logger interface {
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// LoggingOptions : Options for the request logging of VmwareV1
+type LoggingOptions struct {
+	// The logger that receives a record per operation call. Required.
+	Logger Logger
+
+	// Log the request and response headers. The headers of RedactedHeaders, such as Authorization, are always
+	// redacted.
+	LogHeaders bool
+
+	// Log the request and response bodies. The fields of RedactedFields, such as NewPassword.Password, are always
+	// redacted. Bodies of gzip-compressed requests are not logged.
+	LogBodies bool
+}
+
+// EnableLogging : Log every operation
+// Each operation call is logged once it completes, at the info level when it succeeds and at the error level when it
+// fails, with the attributes "operation", "method", "path", "status", "duration" and, when set, "transaction_id" and
+// "error". Headers and bodies are added as "request_headers", "response_headers", "request_body" and
+// "response_body" when enabled in options.
+func (vmware *VmwareV1) EnableLogging(options *LoggingOptions) error {
+	err := core.ValidateNotNil(options, "options cannot be nil")
+	if err != nil {
+		return err
+	}
+	err = core.ValidateNotNil(options.Logger, "options.Logger cannot be nil")
+	if err != nil {
+		return err
+	}
+	resolved := *options
+	vmware.logging = &resolved
+	return nil
+}
+
+// DisableLogging : Stop logging operations
+func (vmware *VmwareV1) DisableLogging() {
+	vmware.logging = nil
+}
+
+// requestLog : The record of a single operation call
+type requestLog struct {
+	options   *LoggingOptions
+	operation string
+
+	// The request body, read before the request is sent.
+	requestBody []byte
+}
+
+// startLog begins the record of an operation call.
+func (options *LoggingOptions) startLog(operation string, request *http.Request) *requestLog {
+	log := &requestLog{
+		options:   options,
+		operation: operation,
+	}
+	if options.LogBodies && request.GetBody != nil && request.Header.Get("Content-Encoding") == "" {
+		if body, err := request.GetBody(); err == nil {
+			log.requestBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+	return log
+}
+
+// end writes the record of the operation call.
+func (log *requestLog) end(request *http.Request, response *core.DetailedResponse, duration time.Duration, transactionID string, err error) {
+	args := []interface{}{
+		"operation", log.operation,
+		"method", request.Method,
+		"path", request.URL.Path,
+	}
+	if response != nil {
+		args = append(args, "status", response.StatusCode)
+	}
+	args = append(args, "duration", duration)
+	if transactionID != "" {
+		args = append(args, "transaction_id", transactionID)
+	}
+	if log.options.LogHeaders {
+		args = append(args, "request_headers", redactHeaders(request.Header))
+		if response != nil {
+			args = append(args, "response_headers", redactHeaders(response.Headers))
+		}
+	}
+	if log.options.LogBodies {
+		if len(log.requestBody) > 0 {
+			args = append(args, "request_body", redactBody(log.requestBody))
+		}
+		if response != nil && response.Result != nil {
+			if body, marshalErr := json.Marshal(response.Result); marshalErr == nil {
+				args = append(args, "response_body", redactBody(body))
+			}
+		}
+	}
+
+	ctx := request.Context()
+	if err != nil {
+		args = append(args, "error", err.Error())
+		log.options.Logger.ErrorContext(ctx, "VMware request failed", args...)
+		return
+	}
+	log.options.Logger.InfoContext(ctx, "VMware request", args...)
+}
+
+// redactHeaders returns a copy of the headers, flattened to single values, with the secret headers redacted.
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for name, values := range RedactHeaders(header) {
+		if len(values) > 0 {
+			redacted[name] = values[0]
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the JSON body with the secret fields redacted. Bodies that are not JSON are replaced entirely,
+// since they cannot be checked for secrets.
+func redactBody(body []byte) string {
+	redacted, err := RedactJSON(body)
+	if err != nil {
+		return Redacted
+	}
+	return string(redacted)
+}
@@ -0,0 +1,50 @@
+//go:build go1.21
+
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 logging with log/slog`, func() {
+	It(`Accept a *slog.Logger`, func() {
+		server := vmwarev1fake.NewServer(nil)
+		defer server.Close()
+		vmwareService, err := server.NewClient()
+		Expect(err).To(BeNil())
+
+		var output bytes.Buffer
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{Logger: slog.New(slog.NewJSONHandler(&output, nil))})).To(Succeed())
+		_, _, err = vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+
+		var record map[string]interface{}
+		Expect(json.Unmarshal(output.Bytes(), &record)).To(Succeed())
+		Expect(record["level"]).To(Equal("INFO"))
+		Expect(record["operation"]).To(Equal("GetRegions"))
+		Expect(record["status"]).To(Equal(float64(200)))
+	})
+})
@@ -0,0 +1,154 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// logRecord is a record received by recordingLogger.
+type logRecord struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+// recordingLogger keeps the records it receives.
+type recordingLogger struct {
+	mutex   sync.Mutex
+	records []logRecord
+}
+
+func (logger *recordingLogger) record(level string, msg string, args []interface{}) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	attrs := make(map[string]interface{})
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[args[i].(string)] = args[i+1]
+	}
+	logger.records = append(logger.records, logRecord{level: level, msg: msg, attrs: attrs})
+}
+
+func (logger *recordingLogger) InfoContext(ctx context.Context, msg string, args ...interface{}) {
+	logger.record("info", msg, args)
+}
+
+func (logger *recordingLogger) ErrorContext(ctx context.Context, msg string, args ...interface{}) {
+	logger.record("error", msg, args)
+}
+
+var _ = Describe(`VmwareV1 logging`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+	var logger *recordingLogger
+	var siteID string
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		var err error
+		vmwareService, err = vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           server.URL,
+			Authenticator: &core.BearerTokenAuthenticator{BearerToken: "secret-token"},
+		})
+		Expect(err).To(BeNil())
+
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		site, _, err := vmwareService.CreateWorkloadDomain(vmwareService.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+		Expect(err).To(BeNil())
+		siteID = *site.ID
+
+		logger = &recordingLogger{}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Log the operation, method, path, status and duration`, func() {
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{Logger: logger})).To(Succeed())
+		_, _, err := vmwareService.GetSpecificWorkloadDomainInstance(vmwareService.NewGetSpecificWorkloadDomainInstanceOptions(siteID).SetXGlobalTransactionID("testTransaction"))
+		Expect(err).To(BeNil())
+
+		Expect(logger.records).To(HaveLen(1))
+		record := logger.records[0]
+		Expect(record.level).To(Equal("info"))
+		Expect(record.attrs["operation"]).To(Equal("GetSpecificWorkloadDomainInstance"))
+		Expect(record.attrs["method"]).To(Equal("GET"))
+		Expect(record.attrs["path"]).To(Equal("/director_sites/" + siteID))
+		Expect(record.attrs["status"]).To(Equal(200))
+		Expect(record.attrs["duration"]).To(BeNumerically(">", time.Duration(0)))
+		Expect(record.attrs["transaction_id"]).To(Equal("testTransaction"))
+		Expect(record.attrs).ToNot(HaveKey("request_headers"))
+		Expect(record.attrs).ToNot(HaveKey("response_body"))
+	})
+	It(`Log failures at the error level`, func() {
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{Logger: logger})).To(Succeed())
+		_, _, err := vmwareService.GetSpecificWorkloadDomainInstance(vmwareService.NewGetSpecificWorkloadDomainInstanceOptions("unknown"))
+		Expect(err).ToNot(BeNil())
+
+		Expect(logger.records).To(HaveLen(1))
+		Expect(logger.records[0].level).To(Equal("error"))
+		Expect(logger.records[0].attrs["status"]).To(Equal(404))
+		Expect(logger.records[0].attrs["error"]).To(Equal(err.Error()))
+	})
+	It(`Redact the Authorization header and the admin password`, func() {
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{Logger: logger, LogHeaders: true, LogBodies: true})).To(Succeed())
+		options := vmwareService.NewReplaceOrgAdminPasswordOptions(siteID).SetHeaders(map[string]string{"proxy-authorization": "secret-proxy"})
+		result, _, err := vmwareService.ReplaceOrgAdminPassword(options)
+		Expect(err).To(BeNil())
+		Expect(*result.Password).ToNot(BeEmpty())
+
+		Expect(logger.records).To(HaveLen(1))
+		attrs := logger.records[0].attrs
+		Expect(attrs["request_headers"]).To(HaveKeyWithValue("Authorization", vmwarev1.Redacted))
+		Expect(attrs["request_headers"]).To(HaveKeyWithValue("proxy-authorization", vmwarev1.Redacted))
+		Expect(attrs["response_body"]).To(Equal(`{"password":"[REDACTED]"}`))
+		Expect(fmt.Sprint(attrs)).ToNot(ContainSubstring("secret-"))
+		Expect(fmt.Sprint(attrs)).ToNot(ContainSubstring(*result.Password))
+	})
+	It(`Log request and response bodies`, func() {
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{Logger: logger, LogBodies: true})).To(Succeed())
+		clusters, _, err := vmwareService.ListClusterInstances(vmwareService.NewListClusterInstancesOptions(siteID))
+		Expect(err).To(BeNil())
+		_, _, err = vmwareService.SetHostsCount(vmwareService.NewSetHostsCountOptions(siteID, *clusters.Clusters[0].ID, 3))
+		Expect(err).To(BeNil())
+
+		Expect(logger.records).To(HaveLen(2))
+		Expect(logger.records[0].attrs["response_body"]).To(ContainSubstring(`"name":"cluster1"`))
+		Expect(logger.records[1].attrs["request_body"]).To(Equal(`{"count":3}`))
+	})
+	It(`Stop logging when disabled`, func() {
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{Logger: logger})).To(Succeed())
+		vmwareService.DisableLogging()
+		_, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(logger.records).To(BeEmpty())
+	})
+	It(`Invoke EnableLogging with error: missing logger`, func() {
+		Expect(vmwareService.EnableLogging(nil)).ToNot(Succeed())
+		Expect(vmwareService.EnableLogging(&vmwarev1.LoggingOptions{})).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,56 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"time"
+)
+
+// MetricsCollector : Receives an observation for every request sent by VmwareV1
+// ObserveRequest is called once per operation call, after any retries, from the goroutine that made the call. It must
+// be safe for concurrent use. See the vmwarev1prom package for a Prometheus adapter.
+type MetricsCollector interface {
+	ObserveRequest(observation RequestObservation)
+}
+
+// RequestObservation : The outcome of a single operation call
+type RequestObservation struct {
+	// The operationId of the operation, such as "SetHostsCount".
+	Operation string
+
+	// The HTTP method of the request.
+	Method string
+
+	// The HTTP status code of the final response, or 0 when no response was received.
+	StatusCode int
+
+	// The time spent sending the request and reading the response, including retries.
+	Duration time.Duration
+
+	// The error returned by the operation, if any.
+	Err error
+}
+
+// EnableMetrics : Report every operation to the collector
+func (vmware *VmwareV1) EnableMetrics(collector MetricsCollector) {
+	vmware.metrics = collector
+}
+
+// DisableMetrics : Stop reporting operations
+func (vmware *VmwareV1) DisableMetrics() {
+	vmware.metrics = nil
+}
@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"sync"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingCollector keeps the observations it receives.
+type recordingCollector struct {
+	mutex        sync.Mutex
+	observations []vmwarev1.RequestObservation
+}
+
+func (collector *recordingCollector) ObserveRequest(observation vmwarev1.RequestObservation) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	collector.observations = append(collector.observations, observation)
+}
+
+var _ = Describe(`VmwareV1 metrics`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+	var collector *recordingCollector
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		var err error
+		vmwareService, err = server.NewClient()
+		Expect(err).To(BeNil())
+		collector = &recordingCollector{}
+		vmwareService.EnableMetrics(collector)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Observe successful and failed operations`, func() {
+		_, _, err := vmwareService.ListPrices(vmwareService.NewListPricesOptions())
+		Expect(err).To(BeNil())
+		server.InjectFault("GetVcddPrice", vmwarev1fake.Fault{StatusCode: 500, Times: 1})
+		_, _, err = vmwareService.GetVcddPrice(vmwareService.NewGetVcddPriceOptions())
+		Expect(err).ToNot(BeNil())
+
+		Expect(collector.observations).To(HaveLen(2))
+		listPrices := collector.observations[0]
+		Expect(listPrices.Operation).To(Equal("ListPrices"))
+		Expect(listPrices.Method).To(Equal("GET"))
+		Expect(listPrices.StatusCode).To(Equal(200))
+		Expect(listPrices.Duration).To(BeNumerically(">", 0))
+		Expect(listPrices.Err).To(BeNil())
+
+		getVcddPrice := collector.observations[1]
+		Expect(getVcddPrice.Operation).To(Equal("GetVcddPrice"))
+		Expect(getVcddPrice.Method).To(Equal("POST"))
+		Expect(getVcddPrice.StatusCode).To(Equal(500))
+		Expect(getVcddPrice.Err).To(Equal(err))
+	})
+	It(`Observe requests that got no response`, func() {
+		Expect(vmwareService.SetServiceURL("http://127.0.0.1:1")).To(Succeed())
+		_, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).ToNot(BeNil())
+		Expect(collector.observations).To(HaveLen(1))
+		Expect(collector.observations[0].StatusCode).To(Equal(0))
+		Expect(collector.observations[0].Err).To(Equal(err))
+	})
+	It(`Stop observing when disabled`, func() {
+		vmwareService.DisableMetrics()
+		_, _, err := vmwareService.GetRegions(vmwareService.NewGetRegionsOptions())
+		Expect(err).To(BeNil())
+		Expect(collector.observations).To(BeEmpty())
+	})
+})
@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces secrets in the request logs, the audit trail and the cassettes of vmwarev1cassette.
+const Redacted = "[REDACTED]"
+
+// RedactedHeaders are the request and response headers that are always redacted.
+var RedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// RedactedFields are the JSON body fields that are always redacted, at any depth. "password" is the
+// NewPassword.Password returned by ReplaceOrgAdminPassword.
+var RedactedFields = []string{"password", "access_token", "refresh_token", "apikey", "api_key"}
+
+// RedactHeaders returns a copy of the headers with the values of RedactedHeaders replaced by Redacted. Header names
+// are matched case-insensitively, since headers set through the Headers of the options keep the case they were given
+// in.
+func RedactHeaders(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		redacted[name] = append([]string(nil), values...)
+		for _, secret := range RedactedHeaders {
+			if strings.EqualFold(name, secret) {
+				redacted[name] = []string{Redacted}
+			}
+		}
+	}
+	return redacted
+}
+
+// RedactJSON returns the JSON document with the values of RedactedFields and of the extra fields replaced by
+// Redacted, at any depth. Numbers are kept exact. An error is returned when body is not a single JSON document.
+func RedactJSON(body []byte, fields ...string) ([]byte, error) {
+	secrets := make(map[string]bool, len(RedactedFields)+len(fields))
+	for _, field := range RedactedFields {
+		secrets[field] = true
+	}
+	for _, field := range fields {
+		secrets[field] = true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("trailing data after JSON document")
+	}
+	return json.Marshal(redactValue(value, secrets))
+}
+
+func redactValue(value interface{}, secrets map[string]bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if secrets[key] {
+				value[key] = Redacted
+			} else {
+				value[key] = redactValue(field, secrets)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = redactValue(value[i], secrets)
+		}
+	}
+	return value
+}
@@ -0,0 +1,52 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"net/http"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 redaction`, func() {
+	It(`Redact the secret headers whatever their case`, func() {
+		header := http.Header{
+			"Authorization": {"Bearer token"},
+			"cookie":        {"a=1", "b=2"},
+			"Accept":        {"application/json"},
+		}
+		redacted := vmwarev1.RedactHeaders(header)
+		Expect(redacted).To(Equal(http.Header{
+			"Authorization": {vmwarev1.Redacted},
+			"cookie":        {vmwarev1.Redacted},
+			"Accept":        {"application/json"},
+		}))
+		Expect(header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+	It(`Redact the secret fields of JSON bodies at any depth`, func() {
+		redacted, err := vmwarev1.RedactJSON([]byte(`{"password":"p","nested":[{"secret":"s","access_token":"t","count":12345678901234567890}]}`), "secret")
+		Expect(err).To(BeNil())
+		Expect(string(redacted)).To(Equal(`{"nested":[{"access_token":"[REDACTED]","count":12345678901234567890,"secret":"[REDACTED]"}],"password":"[REDACTED]"}`))
+
+		_, err = vmwarev1.RedactJSON([]byte(`not json`))
+		Expect(err).ToNot(BeNil())
+		_, err = vmwarev1.RedactJSON([]byte(`{} {}`))
+		Expect(err).ToNot(BeNil())
+	})
+})
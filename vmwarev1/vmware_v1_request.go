@@ -0,0 +1,212 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Operation : An operation of the VMware as a Service API
+type Operation struct {
+	// The operationId, such as "GetSpecificClusterInstance".
+	ID string
+
+	// The HTTP method.
+	Method string
+
+	// The path template, such as "/director_sites/{site_id}/clusters/{cluster_id}".
+	Path string
+}
+
+// operations lists every operation of VmwareV1.
+var operations = []Operation{
+	{"CreateWorkloadDomain", http.MethodPost, "/director_sites"},
+	{"ListWorkloadDomainInstances", http.MethodGet, "/director_sites"},
+	{"GetSpecificWorkloadDomainInstance", http.MethodGet, "/director_sites/{site_id}"},
+	{"DeleteWorkloadDomain", http.MethodDelete, "/director_sites/{site_id}"},
+	{"ListClusterInstances", http.MethodGet, "/director_sites/{site_id}/clusters"},
+	{"GetSpecificClusterInstance", http.MethodGet, "/director_sites/{site_id}/clusters/{cluster_id}"},
+	{"SetHostsCount", http.MethodPut, "/director_sites/{site_id}/clusters/{cluster_id}/hosts_count"},
+	{"SetFileShares", http.MethodPut, "/director_sites/{site_id}/clusters/{cluster_id}/file_shares"},
+	{"GetRegions", http.MethodGet, "/director_site_regions"},
+	{"ViewInstance", http.MethodGet, "/director_site_host_profiles"},
+	{"ReplaceOrgAdminPassword", http.MethodPut, "/director_site_password"},
+	{"ListPrices", http.MethodGet, "/director_site_pricing"},
+	{"GetVcddPrice", http.MethodPost, "/director_site_price_quote"},
+	{"ListVdcs", http.MethodGet, "/vdcs"},
+	{"CreateVdc", http.MethodPost, "/vdcs"},
+	{"GetVdc", http.MethodGet, "/vdcs/{vdc_id}"},
+	{"DeleteVdc", http.MethodDelete, "/vdcs/{vdc_id}"},
+}
+
+// Operations returns every operation of VmwareV1.
+func Operations() []Operation {
+	return append([]Operation(nil), operations...)
+}
+
+// MatchOperation : Find the operation of a request
+// Return the operation with the method whose path template matches the escaped URL path, together with the values of
+// the path parameters. The path may start with the path of the service URL, such as "/v1". When several templates
+// match, the longest one wins.
+func MatchOperation(method string, path string) (operation Operation, params map[string]string, ok bool) {
+	matched := -1
+	for _, candidate := range operations {
+		if candidate.Method != method {
+			continue
+		}
+		candidateParams, candidateOK := matchPath(candidate.Path, path)
+		if candidateOK && len(candidate.Path) > matched {
+			operation, params, ok = candidate, candidateParams, true
+			matched = len(candidate.Path)
+		}
+	}
+	return
+}
+
+// matchPath matches the template against the end of the escaped path and returns the unescaped values of its
+// parameters.
+func matchPath(template string, path string) (map[string]string, bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathSegments) < len(templateSegments) {
+		return nil, false
+	}
+	pathSegments = pathSegments[len(pathSegments)-len(templateSegments):]
+	params := make(map[string]string)
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(pathSegments[i])
+			if err != nil {
+				value = pathSegments[i]
+			}
+			params[strings.Trim(segment, "{}")] = value
+		} else if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// resourceParams returns the site_id, cluster_id and vdc_id parameters of a request of the operation, taken from the
+// path or, for ReplaceOrgAdminPassword, from the query.
+func resourceParams(operation string, request *http.Request) map[string]string {
+	params := make(map[string]string)
+	for _, candidate := range operations {
+		if matched, ok := matchPath(candidate.Path, request.URL.EscapedPath()); ok && candidate.ID == operation {
+			params = matched
+		}
+	}
+	if siteID := request.URL.Query().Get("site_id"); siteID != "" {
+		params["site_id"] = siteID
+	}
+	return params
+}
+
+// countAttempts returns the request with a trace in its context that counts the HTTP attempts made for it, retries
+// included. The attempts are counted when the transport gets a connection, so the HTTP client of the service is left
+// as it is.
+func countAttempts(request *http.Request) (*http.Request, *int32) {
+	attempts := new(int32)
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			atomic.AddInt32(attempts, 1)
+		},
+	}
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), trace)), attempts
+}
+
+// request sends the request built by an operation and converts error responses into *APIError. Every operation of
+// VmwareV1 goes through request, so behavior that applies to all operations belongs here.
+func (vmware *VmwareV1) request(operation string, request *http.Request, result interface{}) (response *core.DetailedResponse, err error) {
+	// Planned requests are not sent, so they are not checked against the budget either: the check calls the service.
+	if vmware.dryRuns(operation, request) {
+		planned, planErr := vmware.planRequest(operation, request)
+		if planErr != nil {
+			return nil, planErr
+		}
+		return nil, &DryRunError{Request: planned}
+	}
+	if vmware.budget != nil && budgeted(operation) {
+		if err = vmware.checkBudget(operation, request); err != nil {
+			return
+		}
+	}
+	if vmware.cache != nil && vmware.cache.caches(operation) {
+		return vmware.cache.do(operation, request, result, vmware.send)
+	}
+	return vmware.send(operation, request, result)
+}
+
+// send sends the request, applying the tracing, metrics, transaction ID, logging and audit hooks.
+func (vmware *VmwareV1) send(operation string, request *http.Request, result interface{}) (response *core.DetailedResponse, err error) {
+	transactionID := vmware.setTransactionID(request)
+
+	var span Span
+	var attempts *int32
+	if vmware.tracer != nil {
+		request, attempts = countAttempts(request)
+		request, span = vmware.startSpan(operation, request)
+	}
+
+	var log *requestLog
+	if vmware.logging != nil {
+		log = vmware.logging.startLog(operation, request)
+	}
+	audit := vmware.startAudit(operation, request)
+
+	start := time.Now()
+	response, err = vmware.Service.Request(request, result)
+	duration := time.Since(start)
+	recordTransactionID(response, transactionID)
+	if err != nil && response != nil && (response.StatusCode < 200 || response.StatusCode >= 300) {
+		err = newAPIError(operation, request, response, err)
+	}
+
+	if span != nil {
+		outcome := SpanOutcome{Response: response, Err: err}
+		if retries := int(atomic.LoadInt32(attempts)) - 1; retries > 0 {
+			outcome.Retries = retries
+		}
+		span.End(outcome)
+	}
+	if vmware.metrics != nil {
+		observation := RequestObservation{
+			Operation: operation,
+			Method:    request.Method,
+			Duration:  duration,
+			Err:       err,
+		}
+		if response != nil {
+			observation.StatusCode = response.StatusCode
+		}
+		vmware.metrics.ObserveRequest(observation)
+	}
+	if log != nil {
+		log.end(request, response, duration, transactionID, err)
+	}
+	if audit != nil {
+		audit.end(request, response, transactionID, err)
+	}
+	return
+}
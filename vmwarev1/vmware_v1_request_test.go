@@ -0,0 +1,57 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 operations`, func() {
+	It(`Match requests to their operation`, func() {
+		operation, params, ok := vmwarev1.MatchOperation("GET", "/v1/director_sites/site%2F1/clusters/cluster1")
+		Expect(ok).To(BeTrue())
+		Expect(operation).To(Equal(vmwarev1.Operation{
+			ID:     "GetSpecificClusterInstance",
+			Method: "GET",
+			Path:   "/director_sites/{site_id}/clusters/{cluster_id}",
+		}))
+		Expect(params).To(Equal(map[string]string{"site_id": "site/1", "cluster_id": "cluster1"}))
+
+		operation, _, ok = vmwarev1.MatchOperation("POST", "/director_sites")
+		Expect(ok).To(BeTrue())
+		Expect(operation.ID).To(Equal("CreateWorkloadDomain"))
+		operation, _, ok = vmwarev1.MatchOperation("DELETE", "/director_sites/site1")
+		Expect(ok).To(BeTrue())
+		Expect(operation.ID).To(Equal("DeleteWorkloadDomain"))
+
+		_, _, ok = vmwarev1.MatchOperation("PATCH", "/director_sites/site1")
+		Expect(ok).To(BeFalse())
+		_, _, ok = vmwarev1.MatchOperation("GET", "/unknown")
+		Expect(ok).To(BeFalse())
+	})
+	It(`List every operation`, func() {
+		operations := vmwarev1.Operations()
+		Expect(operations).To(HaveLen(17))
+		for _, operation := range operations {
+			matched, _, ok := vmwarev1.MatchOperation(operation.Method, operation.Path)
+			Expect(ok).To(BeTrue())
+			Expect(matched).To(Equal(operation))
+		}
+	})
+})
@@ -0,0 +1,125 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ErrNoOpResize is returned by ResizeCluster when the cluster already has the requested number of hosts.
+var ErrNoOpResize = errors.New("cluster already has the requested number of hosts")
+
+// DirectorSiteBusyError is returned by ResizeCluster when the parent director site is already being updated.
+type DirectorSiteBusyError struct {
+	// The ID of the director site.
+	SiteID string
+
+	// The status of the director site.
+	Status string
+}
+
+// Error returns the error message.
+func (e *DirectorSiteBusyError) Error() string {
+	return fmt.Sprintf("director site %s is %s; wait for the running operation to finish before resizing", e.SiteID, e.Status)
+}
+
+// WaitForCluster : Wait for a cluster to reach a target status
+// Poll GetSpecificClusterInstance until the cluster reaches one of opts.TargetStatuses, which defaults to
+// DirectorSite_Status_Readytouse. When neither TargetStatuses nor FailedStatuses is set, DirectorSite_Status_Deleting
+// and DirectorSite_Status_Deleted are treated as failed statuses. The last observed cluster is returned together with
+// any error.
+func (vmware *VmwareV1) WaitForCluster(ctx context.Context, siteID string, clusterID string, opts *WaitOptions) (result *Cluster, err error) {
+	return vmware.waitForCluster(ctx, siteID, clusterID, nil, opts)
+}
+
+// ResizeCluster : Resize a cluster and wait for the resize to finish
+// Set the number of hosts of a cluster with SetHostsCount, which must be at least MinimumClusterHostCount, then poll GetSpecificClusterInstance until Cluster.HostCount
+// equals count and Cluster.Status reaches one of opts.TargetStatuses, which defaults to DirectorSite_Status_Readytouse.
+// ErrNoOpResize is returned without calling SetHostsCount when the cluster already has count hosts, and a
+// *DirectorSiteBusyError is returned when the parent director site is DirectorSite_Status_Updating.
+func (vmware *VmwareV1) ResizeCluster(ctx context.Context, siteID string, clusterID string, count int64, opts *WaitOptions) (result *Cluster, err error) {
+	if siteID == "" || clusterID == "" {
+		err = fmt.Errorf("siteID and clusterID cannot be empty")
+		return
+	}
+	if count < MinimumClusterHostCount {
+		err = fmt.Errorf("count must be at least %d, got %d", MinimumClusterHostCount, count)
+		return
+	}
+
+	site, _, err := vmware.GetSpecificWorkloadDomainInstanceWithContext(ctx, vmware.NewGetSpecificWorkloadDomainInstanceOptions(siteID))
+	if err != nil {
+		return
+	}
+	if core.StringNilMapper(site.Status) == DirectorSite_Status_Updating {
+		err = &DirectorSiteBusyError{SiteID: siteID, Status: *site.Status}
+		return
+	}
+
+	result, _, err = vmware.GetSpecificClusterInstanceWithContext(ctx, vmware.NewGetSpecificClusterInstanceOptions(siteID, clusterID))
+	if err != nil {
+		return
+	}
+	if result.HostCount != nil && *result.HostCount == count {
+		err = ErrNoOpResize
+		return
+	}
+
+	_, _, err = vmware.SetHostsCountWithContext(ctx, vmware.NewSetHostsCountOptions(siteID, clusterID, count))
+	if err != nil {
+		return
+	}
+
+	return vmware.waitForCluster(ctx, siteID, clusterID, func(cluster *Cluster) bool {
+		return cluster.HostCount != nil && *cluster.HostCount == count
+	}, opts)
+}
+
+// waitForCluster polls the cluster until its status is a target status and, when ready is set, ready reports true.
+func (vmware *VmwareV1) waitForCluster(ctx context.Context, siteID string, clusterID string, ready func(cluster *Cluster) bool, opts *WaitOptions) (result *Cluster, err error) {
+	if siteID == "" || clusterID == "" {
+		err = fmt.Errorf("siteID and clusterID cannot be empty")
+		return
+	}
+	opts = opts.withDefaults(
+		[]string{DirectorSite_Status_Readytouse},
+		[]string{DirectorSite_Status_Deleting, DirectorSite_Status_Deleted},
+	)
+
+	var settled func() bool
+	if ready != nil {
+		settled = func() bool {
+			return ready(result)
+		}
+	}
+
+	getOptions := vmware.NewGetSpecificClusterInstanceOptions(siteID, clusterID)
+	err = opts.poll(ctx, "cluster", clusterID, func(ctx context.Context) (status string, err error) {
+		cluster, _, err := vmware.GetSpecificClusterInstanceWithContext(ctx, getOptions)
+		if err != nil {
+			return
+		}
+		result = cluster
+		status = core.StringNilMapper(cluster.Status)
+		return
+	}, settled)
+	return
+}
@@ -0,0 +1,142 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 cluster resize`, func() {
+	var testServer *httptest.Server
+	var mutex sync.Mutex
+	var siteStatus string
+	var hostCount int64
+	var targetCount int64
+	var clusterPolls int
+	var setHostsCountCalls int
+
+	BeforeEach(func() {
+		siteStatus = vmwarev1.DirectorSite_Status_Readytouse
+		hostCount = 2
+		targetCount = 0
+		clusterPolls = 0
+		setHostsCountCalls = 0
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			res.Header().Set("Content-type", "application/json")
+			switch {
+			case req.Method == "GET" && req.URL.EscapedPath() == "/director_sites/testSite":
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `{"id": "testSite", "status": "%s"}`, siteStatus)
+			case req.Method == "GET" && req.URL.EscapedPath() == "/director_sites/testSite/clusters/testCluster":
+				status := vmwarev1.DirectorSite_Status_Readytouse
+				if targetCount != 0 {
+					// Report the old host count as ready once, then move through Updating to the target.
+					clusterPolls++
+					switch {
+					case clusterPolls == 2:
+						status = vmwarev1.DirectorSite_Status_Updating
+					case clusterPolls >= 3:
+						hostCount = targetCount
+					}
+				}
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `{"id": "testCluster", "site_id": "testSite", "host_count": %d, "status": "%s"}`, hostCount, status)
+			case req.Method == "PUT" && req.URL.EscapedPath() == "/director_sites/testSite/clusters/testCluster/hosts_count":
+				var body map[string]int64
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				setHostsCountCalls++
+				targetCount = body["count"]
+				res.WriteHeader(202)
+				fmt.Fprintf(res, "%s", `{"message": "The request has been accepted."}`)
+			default:
+				Fail(fmt.Sprintf("unexpected request %s %s", req.Method, req.URL.EscapedPath()))
+			}
+		}))
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+	newService := func() *vmwarev1.VmwareV1 {
+		vmwareService, serviceErr := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		return vmwareService
+	}
+	fastWait := &vmwarev1.WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      5 * time.Second,
+	}
+
+	Describe(`ResizeCluster(ctx context.Context, siteID string, clusterID string, count int64, opts *WaitOptions)`, func() {
+		It(`Resize the cluster and wait for the new host count`, func() {
+			cluster, err := newService().ResizeCluster(context.Background(), "testSite", "testCluster", 4, fastWait)
+			Expect(err).To(BeNil())
+			Expect(*cluster.HostCount).To(Equal(int64(4)))
+			Expect(*cluster.Status).To(Equal(vmwarev1.DirectorSite_Status_Readytouse))
+			Expect(setHostsCountCalls).To(Equal(1))
+			Expect(clusterPolls).To(Equal(3))
+		})
+		It(`Refuse a no-op resize`, func() {
+			_, err := newService().ResizeCluster(context.Background(), "testSite", "testCluster", 2, fastWait)
+			Expect(errors.Is(err, vmwarev1.ErrNoOpResize)).To(BeTrue())
+			Expect(setHostsCountCalls).To(Equal(0))
+		})
+		It(`Refuse to resize while the director site is updating`, func() {
+			siteStatus = vmwarev1.DirectorSite_Status_Updating
+
+			_, err := newService().ResizeCluster(context.Background(), "testSite", "testCluster", 4, fastWait)
+			var busyErr *vmwarev1.DirectorSiteBusyError
+			Expect(errors.As(err, &busyErr)).To(BeTrue())
+			Expect(busyErr.SiteID).To(Equal("testSite"))
+			Expect(setHostsCountCalls).To(Equal(0))
+		})
+		It(`Invoke ResizeCluster with error: invalid parameters`, func() {
+			_, err := newService().ResizeCluster(context.Background(), "", "testCluster", 4, fastWait)
+			Expect(err).ToNot(BeNil())
+			_, err = newService().ResizeCluster(context.Background(), "testSite", "testCluster", 0, fastWait)
+			Expect(err).ToNot(BeNil())
+			_, err = newService().ResizeCluster(context.Background(), "testSite", "testCluster", vmwarev1.MinimumClusterHostCount-1, fastWait)
+			Expect(err).ToNot(BeNil())
+			Expect(setHostsCountCalls).To(Equal(0))
+		})
+	})
+	Describe(`WaitForCluster(ctx context.Context, siteID string, clusterID string, opts *WaitOptions)`, func() {
+		It(`Wait for the cluster to be ready to use`, func() {
+			cluster, err := newService().WaitForCluster(context.Background(), "testSite", "testCluster", fastWait)
+			Expect(err).To(BeNil())
+			Expect(*cluster.ID).To(Equal("testCluster"))
+		})
+	})
+})
@@ -2698,6 +2698,8 @@ var _ = Describe(`VmwareV1`, func() {
 					// Verify the contents of the request
 					Expect(req.URL.EscapedPath()).To(Equal(replaceOrgAdminPasswordPath))
 					Expect(req.Method).To(Equal("PUT"))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					Expect(req.URL.Query()["site_id"]).To(Equal([]string{"testString"}))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
@@ -2715,6 +2717,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ReplaceOrgAdminPasswordOptions model
 				replaceOrgAdminPasswordOptionsModel := new(vmwarev1.ReplaceOrgAdminPasswordOptions)
 				replaceOrgAdminPasswordOptionsModel.SiteID = core.StringPtr("testString")
+				replaceOrgAdminPasswordOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				replaceOrgAdminPasswordOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
 				result, response, operationErr := vmwareService.ReplaceOrgAdminPassword(replaceOrgAdminPasswordOptionsModel)
@@ -2745,6 +2748,8 @@ var _ = Describe(`VmwareV1`, func() {
 					Expect(req.URL.EscapedPath()).To(Equal(replaceOrgAdminPasswordPath))
 					Expect(req.Method).To(Equal("PUT"))
 
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					Expect(req.URL.Query()["site_id"]).To(Equal([]string{"testString"}))
 					// Sleep a short time to support a timeout test
 					time.Sleep(100 * time.Millisecond)
@@ -2767,6 +2772,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ReplaceOrgAdminPasswordOptions model
 				replaceOrgAdminPasswordOptionsModel := new(vmwarev1.ReplaceOrgAdminPasswordOptions)
 				replaceOrgAdminPasswordOptionsModel.SiteID = core.StringPtr("testString")
+				replaceOrgAdminPasswordOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				replaceOrgAdminPasswordOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with a Context to test a timeout error
@@ -2803,6 +2809,8 @@ var _ = Describe(`VmwareV1`, func() {
 					Expect(req.URL.EscapedPath()).To(Equal(replaceOrgAdminPasswordPath))
 					Expect(req.Method).To(Equal("PUT"))
 
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					Expect(req.URL.Query()["site_id"]).To(Equal([]string{"testString"}))
 					// Set mock response
 					res.Header().Set("Content-type", "application/json")
@@ -2827,6 +2835,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ReplaceOrgAdminPasswordOptions model
 				replaceOrgAdminPasswordOptionsModel := new(vmwarev1.ReplaceOrgAdminPasswordOptions)
 				replaceOrgAdminPasswordOptionsModel.SiteID = core.StringPtr("testString")
+				replaceOrgAdminPasswordOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				replaceOrgAdminPasswordOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with valid options model (positive test)
@@ -2847,6 +2856,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ReplaceOrgAdminPasswordOptions model
 				replaceOrgAdminPasswordOptionsModel := new(vmwarev1.ReplaceOrgAdminPasswordOptions)
 				replaceOrgAdminPasswordOptionsModel.SiteID = core.StringPtr("testString")
+				replaceOrgAdminPasswordOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				replaceOrgAdminPasswordOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
 				err := vmwareService.SetServiceURL("")
@@ -2888,6 +2898,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ReplaceOrgAdminPasswordOptions model
 				replaceOrgAdminPasswordOptionsModel := new(vmwarev1.ReplaceOrgAdminPasswordOptions)
 				replaceOrgAdminPasswordOptionsModel.SiteID = core.StringPtr("testString")
+				replaceOrgAdminPasswordOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				replaceOrgAdminPasswordOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation
@@ -3471,6 +3482,8 @@ var _ = Describe(`VmwareV1`, func() {
 					Expect(req.Method).To(Equal("GET"))
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
 					fmt.Fprint(res, `} this is not valid json {`)
@@ -3487,6 +3500,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ListVdcsOptions model
 				listVdcsOptionsModel := new(vmwarev1.ListVdcsOptions)
 				listVdcsOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				listVdcsOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				listVdcsOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
 				result, response, operationErr := vmwareService.ListVdcs(listVdcsOptionsModel)
@@ -3519,6 +3533,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Sleep a short time to support a timeout test
 					time.Sleep(100 * time.Millisecond)
 
@@ -3540,6 +3556,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ListVdcsOptions model
 				listVdcsOptionsModel := new(vmwarev1.ListVdcsOptions)
 				listVdcsOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				listVdcsOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				listVdcsOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with a Context to test a timeout error
@@ -3578,6 +3595,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Set mock response
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
@@ -3601,6 +3620,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ListVdcsOptions model
 				listVdcsOptionsModel := new(vmwarev1.ListVdcsOptions)
 				listVdcsOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				listVdcsOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				listVdcsOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with valid options model (positive test)
@@ -3621,6 +3641,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ListVdcsOptions model
 				listVdcsOptionsModel := new(vmwarev1.ListVdcsOptions)
 				listVdcsOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				listVdcsOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				listVdcsOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
 				err := vmwareService.SetServiceURL("")
@@ -3655,6 +3676,7 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ListVdcsOptions model
 				listVdcsOptionsModel := new(vmwarev1.ListVdcsOptions)
 				listVdcsOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				listVdcsOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				listVdcsOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation
@@ -3682,6 +3704,8 @@ var _ = Describe(`VmwareV1`, func() {
 					Expect(req.Method).To(Equal("POST"))
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(202)
 					fmt.Fprint(res, `} this is not valid json {`)
@@ -3720,6 +3744,7 @@ var _ = Describe(`VmwareV1`, func() {
 				createVdcOptionsModel.Edge = newVdcEdgeModel
 				createVdcOptionsModel.ResourceGroup = newVdcResourceGroupModel
 				createVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				createVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				createVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
 				result, response, operationErr := vmwareService.CreateVdc(createVdcOptionsModel)
@@ -3768,6 +3793,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Sleep a short time to support a timeout test
 					time.Sleep(100 * time.Millisecond)
 
@@ -3811,6 +3838,7 @@ var _ = Describe(`VmwareV1`, func() {
 				createVdcOptionsModel.Edge = newVdcEdgeModel
 				createVdcOptionsModel.ResourceGroup = newVdcResourceGroupModel
 				createVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				createVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				createVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with a Context to test a timeout error
@@ -3865,6 +3893,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Set mock response
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(202)
@@ -3910,6 +3940,7 @@ var _ = Describe(`VmwareV1`, func() {
 				createVdcOptionsModel.Edge = newVdcEdgeModel
 				createVdcOptionsModel.ResourceGroup = newVdcResourceGroupModel
 				createVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				createVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				createVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with valid options model (positive test)
@@ -3952,6 +3983,7 @@ var _ = Describe(`VmwareV1`, func() {
 				createVdcOptionsModel.Edge = newVdcEdgeModel
 				createVdcOptionsModel.ResourceGroup = newVdcResourceGroupModel
 				createVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				createVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				createVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
 				err := vmwareService.SetServiceURL("")
@@ -4015,6 +4047,7 @@ var _ = Describe(`VmwareV1`, func() {
 				createVdcOptionsModel.Edge = newVdcEdgeModel
 				createVdcOptionsModel.ResourceGroup = newVdcResourceGroupModel
 				createVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				createVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				createVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation
@@ -4042,6 +4075,8 @@ var _ = Describe(`VmwareV1`, func() {
 					Expect(req.Method).To(Equal("GET"))
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
 					fmt.Fprint(res, `} this is not valid json {`)
@@ -4059,6 +4094,7 @@ var _ = Describe(`VmwareV1`, func() {
 				getVdcOptionsModel := new(vmwarev1.GetVdcOptions)
 				getVdcOptionsModel.VdcID = core.StringPtr("testString")
 				getVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				getVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				getVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
 				result, response, operationErr := vmwareService.GetVdc(getVdcOptionsModel)
@@ -4091,6 +4127,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Sleep a short time to support a timeout test
 					time.Sleep(100 * time.Millisecond)
 
@@ -4113,6 +4151,7 @@ var _ = Describe(`VmwareV1`, func() {
 				getVdcOptionsModel := new(vmwarev1.GetVdcOptions)
 				getVdcOptionsModel.VdcID = core.StringPtr("testString")
 				getVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				getVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				getVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with a Context to test a timeout error
@@ -4151,6 +4190,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Set mock response
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(200)
@@ -4175,6 +4216,7 @@ var _ = Describe(`VmwareV1`, func() {
 				getVdcOptionsModel := new(vmwarev1.GetVdcOptions)
 				getVdcOptionsModel.VdcID = core.StringPtr("testString")
 				getVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				getVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				getVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with valid options model (positive test)
@@ -4196,6 +4238,7 @@ var _ = Describe(`VmwareV1`, func() {
 				getVdcOptionsModel := new(vmwarev1.GetVdcOptions)
 				getVdcOptionsModel.VdcID = core.StringPtr("testString")
 				getVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				getVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				getVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
 				err := vmwareService.SetServiceURL("")
@@ -4238,6 +4281,7 @@ var _ = Describe(`VmwareV1`, func() {
 				getVdcOptionsModel := new(vmwarev1.GetVdcOptions)
 				getVdcOptionsModel.VdcID = core.StringPtr("testString")
 				getVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				getVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				getVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation
@@ -4265,6 +4309,8 @@ var _ = Describe(`VmwareV1`, func() {
 					Expect(req.Method).To(Equal("DELETE"))
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(202)
 					fmt.Fprint(res, `} this is not valid json {`)
@@ -4282,6 +4328,7 @@ var _ = Describe(`VmwareV1`, func() {
 				deleteVdcOptionsModel := new(vmwarev1.DeleteVdcOptions)
 				deleteVdcOptionsModel.VdcID = core.StringPtr("testString")
 				deleteVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				deleteVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				deleteVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Expect response parsing to fail since we are receiving a text/plain response
 				result, response, operationErr := vmwareService.DeleteVdc(deleteVdcOptionsModel)
@@ -4314,6 +4361,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Sleep a short time to support a timeout test
 					time.Sleep(100 * time.Millisecond)
 
@@ -4336,6 +4385,7 @@ var _ = Describe(`VmwareV1`, func() {
 				deleteVdcOptionsModel := new(vmwarev1.DeleteVdcOptions)
 				deleteVdcOptionsModel.VdcID = core.StringPtr("testString")
 				deleteVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				deleteVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				deleteVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with a Context to test a timeout error
@@ -4374,6 +4424,8 @@ var _ = Describe(`VmwareV1`, func() {
 
 					Expect(req.Header["Accept-Language"]).ToNot(BeNil())
 					Expect(req.Header["Accept-Language"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
+					Expect(req.Header["X-Global-Transaction-Id"]).ToNot(BeNil())
+					Expect(req.Header["X-Global-Transaction-Id"][0]).To(Equal(fmt.Sprintf("%v", "testString")))
 					// Set mock response
 					res.Header().Set("Content-type", "application/json")
 					res.WriteHeader(202)
@@ -4398,6 +4450,7 @@ var _ = Describe(`VmwareV1`, func() {
 				deleteVdcOptionsModel := new(vmwarev1.DeleteVdcOptions)
 				deleteVdcOptionsModel.VdcID = core.StringPtr("testString")
 				deleteVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				deleteVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				deleteVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation with valid options model (positive test)
@@ -4419,6 +4472,7 @@ var _ = Describe(`VmwareV1`, func() {
 				deleteVdcOptionsModel := new(vmwarev1.DeleteVdcOptions)
 				deleteVdcOptionsModel.VdcID = core.StringPtr("testString")
 				deleteVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				deleteVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				deleteVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 				// Invoke operation with empty URL (negative test)
 				err := vmwareService.SetServiceURL("")
@@ -4461,6 +4515,7 @@ var _ = Describe(`VmwareV1`, func() {
 				deleteVdcOptionsModel := new(vmwarev1.DeleteVdcOptions)
 				deleteVdcOptionsModel.VdcID = core.StringPtr("testString")
 				deleteVdcOptionsModel.AcceptLanguage = core.StringPtr("testString")
+				deleteVdcOptionsModel.XGlobalTransactionID = core.StringPtr("testString")
 				deleteVdcOptionsModel.Headers = map[string]string{"x-custom-header": "x-custom-value"}
 
 				// Invoke operation
@@ -4529,6 +4584,7 @@ var _ = Describe(`VmwareV1`, func() {
 				createVdcOptionsModel.SetEdge(newVdcEdgeModel)
 				createVdcOptionsModel.SetResourceGroup(newVdcResourceGroupModel)
 				createVdcOptionsModel.SetAcceptLanguage("testString")
+				createVdcOptionsModel.SetXGlobalTransactionID("testString")
 				createVdcOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(createVdcOptionsModel).ToNot(BeNil())
 				Expect(createVdcOptionsModel.Name).To(Equal(core.StringPtr("testString")))
@@ -4536,6 +4592,7 @@ var _ = Describe(`VmwareV1`, func() {
 				Expect(createVdcOptionsModel.Edge).To(Equal(newVdcEdgeModel))
 				Expect(createVdcOptionsModel.ResourceGroup).To(Equal(newVdcResourceGroupModel))
 				Expect(createVdcOptionsModel.AcceptLanguage).To(Equal(core.StringPtr("testString")))
+				Expect(createVdcOptionsModel.XGlobalTransactionID).To(Equal(core.StringPtr("testString")))
 				Expect(createVdcOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
 			It(`Invoke NewCreateWorkloadDomainOptions successfully`, func() {
@@ -4590,10 +4647,12 @@ var _ = Describe(`VmwareV1`, func() {
 				deleteVdcOptionsModel := vmwareService.NewDeleteVdcOptions(vdcID)
 				deleteVdcOptionsModel.SetVdcID("testString")
 				deleteVdcOptionsModel.SetAcceptLanguage("testString")
+				deleteVdcOptionsModel.SetXGlobalTransactionID("testString")
 				deleteVdcOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(deleteVdcOptionsModel).ToNot(BeNil())
 				Expect(deleteVdcOptionsModel.VdcID).To(Equal(core.StringPtr("testString")))
 				Expect(deleteVdcOptionsModel.AcceptLanguage).To(Equal(core.StringPtr("testString")))
+				Expect(deleteVdcOptionsModel.XGlobalTransactionID).To(Equal(core.StringPtr("testString")))
 				Expect(deleteVdcOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
 			It(`Invoke NewDeleteWorkloadDomainOptions successfully`, func() {
@@ -4697,10 +4756,12 @@ var _ = Describe(`VmwareV1`, func() {
 				getVdcOptionsModel := vmwareService.NewGetVdcOptions(vdcID)
 				getVdcOptionsModel.SetVdcID("testString")
 				getVdcOptionsModel.SetAcceptLanguage("testString")
+				getVdcOptionsModel.SetXGlobalTransactionID("testString")
 				getVdcOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(getVdcOptionsModel).ToNot(BeNil())
 				Expect(getVdcOptionsModel.VdcID).To(Equal(core.StringPtr("testString")))
 				Expect(getVdcOptionsModel.AcceptLanguage).To(Equal(core.StringPtr("testString")))
+				Expect(getVdcOptionsModel.XGlobalTransactionID).To(Equal(core.StringPtr("testString")))
 				Expect(getVdcOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
 			It(`Invoke NewListClusterInstancesOptions successfully`, func() {
@@ -4732,9 +4793,11 @@ var _ = Describe(`VmwareV1`, func() {
 				// Construct an instance of the ListVdcsOptions model
 				listVdcsOptionsModel := vmwareService.NewListVdcsOptions()
 				listVdcsOptionsModel.SetAcceptLanguage("testString")
+				listVdcsOptionsModel.SetXGlobalTransactionID("testString")
 				listVdcsOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(listVdcsOptionsModel).ToNot(BeNil())
 				Expect(listVdcsOptionsModel.AcceptLanguage).To(Equal(core.StringPtr("testString")))
+				Expect(listVdcsOptionsModel.XGlobalTransactionID).To(Equal(core.StringPtr("testString")))
 				Expect(listVdcsOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
 			It(`Invoke NewListWorkloadDomainInstancesOptions successfully`, func() {
@@ -4771,9 +4834,11 @@ var _ = Describe(`VmwareV1`, func() {
 				siteID := "testString"
 				replaceOrgAdminPasswordOptionsModel := vmwareService.NewReplaceOrgAdminPasswordOptions(siteID)
 				replaceOrgAdminPasswordOptionsModel.SetSiteID("testString")
+				replaceOrgAdminPasswordOptionsModel.SetXGlobalTransactionID("testString")
 				replaceOrgAdminPasswordOptionsModel.SetHeaders(map[string]string{"foo": "bar"})
 				Expect(replaceOrgAdminPasswordOptionsModel).ToNot(BeNil())
 				Expect(replaceOrgAdminPasswordOptionsModel.SiteID).To(Equal(core.StringPtr("testString")))
+				Expect(replaceOrgAdminPasswordOptionsModel.XGlobalTransactionID).To(Equal(core.StringPtr("testString")))
 				Expect(replaceOrgAdminPasswordOptionsModel.Headers).To(Equal(map[string]string{"foo": "bar"}))
 			})
 			It(`Invoke NewSetFileSharesOptions successfully`, func() {
@@ -0,0 +1,145 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTimestampNotSet is returned by the timestamp accessors of Cluster and DirectorSite when the service did not
+// return the timestamp, for example InstanceCreated while the instance is still being created.
+var ErrTimestampNotSet = errors.New("timestamp not set")
+
+// timestampLayouts are the layouts tried by ParseTimestamp, in order. Layouts without a time zone are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02",
+}
+
+// ParseTimestamp : Parse a timestamp returned by the service
+// RFC 3339 timestamps are accepted with or without fractional seconds, with a time zone offset with or without a
+// colon, or without any time zone, in which case they are read as UTC. A space may separate the date and the time.
+// RFC 1123 timestamps, dates such as "2022-11-15", and Unix times in seconds or milliseconds are accepted too.
+func ParseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, ErrTimestampNotSet
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		// Unix times in milliseconds have at least 13 digits since 2001.
+		if seconds >= 1e12 || seconds <= -1e12 {
+			return time.UnixMilli(seconds).UTC(), nil
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
+
+// parseTimestampField parses an optional timestamp field of a model.
+func parseTimestampField(name string, value *string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("%s: %w", name, ErrTimestampNotSet)
+	}
+	parsed, err := ParseTimestamp(*value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+// between returns the time from start to end, failing when either is missing or invalid.
+func between(start func() (time.Time, error), end func() (time.Time, error)) (time.Duration, error) {
+	from, err := start()
+	if err != nil {
+		return 0, err
+	}
+	to, err := end()
+	if err != nil {
+		return 0, err
+	}
+	return to.Sub(from), nil
+}
+
+// OrderedTime returns InstanceOrdered as a time.
+func (cluster *Cluster) OrderedTime() (time.Time, error) {
+	return parseTimestampField("instance_ordered", cluster.InstanceOrdered)
+}
+
+// CreatedTime returns InstanceCreated as a time.
+func (cluster *Cluster) CreatedTime() (time.Time, error) {
+	return parseTimestampField("instance_created", cluster.InstanceCreated)
+}
+
+// DeletedTime returns InstanceDeleted as a time.
+func (cluster *Cluster) DeletedTime() (time.Time, error) {
+	return parseTimestampField("instance_deleted", cluster.InstanceDeleted)
+}
+
+// ProvisioningDuration returns the time from the order of the cluster to its creation.
+func (cluster *Cluster) ProvisioningDuration() (time.Duration, error) {
+	return between(cluster.OrderedTime, cluster.CreatedTime)
+}
+
+// Age returns the time from the creation of the cluster to now, or to its deletion when it was deleted before now.
+func (cluster *Cluster) Age(now time.Time) (time.Duration, error) {
+	end := func() (time.Time, error) {
+		if deleted, err := cluster.DeletedTime(); err == nil && deleted.Before(now) {
+			return deleted, nil
+		}
+		return now, nil
+	}
+	return between(cluster.CreatedTime, end)
+}
+
+// OrderedTime returns InstanceOrdered as a time.
+func (directorSite *DirectorSite) OrderedTime() (time.Time, error) {
+	return parseTimestampField("instance_ordered", directorSite.InstanceOrdered)
+}
+
+// CreatedTime returns InstanceCreated as a time.
+func (directorSite *DirectorSite) CreatedTime() (time.Time, error) {
+	return parseTimestampField("instance_created", directorSite.InstanceCreated)
+}
+
+// ProvisioningDuration returns the time from the order of the director site to its creation.
+func (directorSite *DirectorSite) ProvisioningDuration() (time.Duration, error) {
+	return between(directorSite.OrderedTime, directorSite.CreatedTime)
+}
+
+// Age returns the time from the creation of the director site to now.
+func (directorSite *DirectorSite) Age(now time.Time) (time.Duration, error) {
+	return between(directorSite.CreatedTime, func() (time.Time, error) {
+		return now, nil
+	})
+}
@@ -0,0 +1,128 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 timestamps`, func() {
+	Describe(`ParseTimestamp(value string)`, func() {
+		expected := time.Date(2022, 11, 15, 12, 0, 0, 0, time.UTC)
+		for _, value := range []string{
+			"2022-11-15T12:00:00Z",
+			"2022-11-15T12:00:00.000Z",
+			"2022-11-15T13:00:00+01:00",
+			"2022-11-15T13:00:00+0100",
+			"2022-11-15T12:00:00",
+			"2022-11-15 12:00:00",
+			"2022-11-15 12:00:00Z",
+			"2022-11-15 12:00:00 +0000 UTC",
+			"Tue, 15 Nov 2022 12:00:00 GMT",
+			"1668513600",
+			"1668513600000",
+			" 2022-11-15T12:00:00Z ",
+		} {
+			value := value
+			It(`Parse `+value, func() {
+				parsed, err := vmwarev1.ParseTimestamp(value)
+				Expect(err).To(BeNil())
+				Expect(parsed.Equal(expected)).To(BeTrue(), parsed.String())
+			})
+		}
+		It(`Parse fractional seconds and dates`, func() {
+			parsed, err := vmwarev1.ParseTimestamp("2022-11-15T12:00:00.123456Z")
+			Expect(err).To(BeNil())
+			Expect(parsed.Nanosecond()).To(Equal(123456000))
+			parsed, err = vmwarev1.ParseTimestamp("2022-11-15")
+			Expect(err).To(BeNil())
+			Expect(parsed).To(Equal(time.Date(2022, 11, 15, 0, 0, 0, 0, time.UTC)))
+		})
+		It(`Invoke ParseTimestamp with error: invalid timestamps`, func() {
+			_, err := vmwarev1.ParseTimestamp("yesterday")
+			Expect(err).ToNot(BeNil())
+			_, err = vmwarev1.ParseTimestamp("")
+			Expect(errors.Is(err, vmwarev1.ErrTimestampNotSet)).To(BeTrue())
+		})
+	})
+
+	It(`Derive durations from the timestamps of a cluster`, func() {
+		cluster := &vmwarev1.Cluster{
+			InstanceOrdered: core.StringPtr("2022-11-15T12:00:00Z"),
+			InstanceCreated: core.StringPtr("2022-11-15T15:30:00Z"),
+		}
+		ordered, err := cluster.OrderedTime()
+		Expect(err).To(BeNil())
+		Expect(ordered).To(Equal(time.Date(2022, 11, 15, 12, 0, 0, 0, time.UTC)))
+		provisioning, err := cluster.ProvisioningDuration()
+		Expect(err).To(BeNil())
+		Expect(provisioning).To(Equal(3*time.Hour + 30*time.Minute))
+
+		now := time.Date(2022, 11, 16, 15, 30, 0, 0, time.UTC)
+		age, err := cluster.Age(now)
+		Expect(err).To(BeNil())
+		Expect(age).To(Equal(24 * time.Hour))
+
+		_, err = cluster.DeletedTime()
+		Expect(errors.Is(err, vmwarev1.ErrTimestampNotSet)).To(BeTrue())
+		cluster.InstanceDeleted = core.StringPtr("2022-11-16T03:30:00Z")
+		age, err = cluster.Age(now)
+		Expect(err).To(BeNil())
+		Expect(age).To(Equal(12 * time.Hour))
+
+		cluster.InstanceCreated = core.StringPtr("soon")
+		_, err = cluster.ProvisioningDuration()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("instance_created"))
+	})
+	It(`Derive durations from the timestamps of a director site`, func() {
+		now := time.Date(2022, 11, 15, 12, 0, 0, 0, time.UTC)
+		server := vmwarev1fake.NewServer(&vmwarev1fake.ServerOptions{
+			TransitionDelay: time.Hour,
+			Now: func() time.Time {
+				return now
+			},
+		})
+		defer server.Close()
+		vmwareService, err := server.NewClient()
+		Expect(err).To(BeNil())
+
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		site, _, err := vmwareService.CreateWorkloadDomain(vmwareService.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+		Expect(err).To(BeNil())
+		_, err = site.ProvisioningDuration()
+		Expect(errors.Is(err, vmwarev1.ErrTimestampNotSet)).To(BeTrue())
+
+		now = now.Add(90 * time.Minute)
+		site, _, err = vmwareService.GetSpecificWorkloadDomainInstance(vmwareService.NewGetSpecificWorkloadDomainInstanceOptions(*site.ID))
+		Expect(err).To(BeNil())
+		provisioning, err := site.ProvisioningDuration()
+		Expect(err).To(BeNil())
+		Expect(provisioning).To(Equal(90 * time.Minute))
+		age, err := site.Age(now.Add(time.Hour))
+		Expect(err).To(BeNil())
+		Expect(age).To(Equal(time.Hour))
+	})
+})
@@ -0,0 +1,87 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Tracer : Starts a span for every operation sent by VmwareV1
+// StartSpan is called once per operation call, before the request is sent, from the goroutine that made the call. It
+// must be safe for concurrent use. See the vmwarev1otel package for an OpenTelemetry adapter.
+type Tracer interface {
+	// StartSpan starts the span of the operation and returns the request to send in its place, which may carry the
+	// span in its context and the trace context in its headers.
+	StartSpan(operation TracedOperation) (*http.Request, Span)
+}
+
+// Span : The span of a single operation call
+type Span interface {
+	// End records the outcome of the operation and ends the span. It is called once, after any retries.
+	End(outcome SpanOutcome)
+}
+
+// TracedOperation : An operation call about to be sent
+type TracedOperation struct {
+	// The operationId of the operation, such as "CreateWorkloadDomain".
+	Operation string
+
+	// The request of the operation. Its context carries the context passed to the WithContext method.
+	Request *http.Request
+
+	// The site_id, cluster_id and vdc_id of the request, when it has them. The site_id of ReplaceOrgAdminPassword is
+	// taken from the query.
+	SiteID    string
+	ClusterID string
+	VdcID     string
+}
+
+// SpanOutcome : The outcome of a single operation call
+type SpanOutcome struct {
+	// The final response, or nil when no response was received.
+	Response *core.DetailedResponse
+
+	// The number of times the request was retried by the HTTP client of the service.
+	Retries int
+
+	// The error returned by the operation, if any.
+	Err error
+}
+
+// EnableTracing : Start a span for every operation with the tracer
+func (vmware *VmwareV1) EnableTracing(tracer Tracer) {
+	vmware.tracer = tracer
+}
+
+// DisableTracing : Stop tracing operations
+func (vmware *VmwareV1) DisableTracing() {
+	vmware.tracer = nil
+}
+
+// startSpan starts the span of the operation and returns the request to send in its place.
+func (vmware *VmwareV1) startSpan(operation string, request *http.Request) (*http.Request, Span) {
+	params := resourceParams(operation, request)
+	return vmware.tracer.StartSpan(TracedOperation{
+		Operation: operation,
+		Request:   request,
+		SiteID:    params["site_id"],
+		ClusterID: params["cluster_id"],
+		VdcID:     params["vdc_id"],
+	})
+}
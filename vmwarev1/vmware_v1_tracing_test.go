@@ -0,0 +1,146 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingTracer : A Tracer that records the operations and outcomes of its spans
+type recordingTracer struct {
+	mutex      sync.Mutex
+	operations []vmwarev1.TracedOperation
+	outcomes   []vmwarev1.SpanOutcome
+}
+
+func (tracer *recordingTracer) StartSpan(operation vmwarev1.TracedOperation) (*http.Request, vmwarev1.Span) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	tracer.operations = append(tracer.operations, operation)
+	request := operation.Request.Clone(operation.Request.Context())
+	request.Header.Set("X-Test-Span", operation.Operation)
+	return request, recordingSpan{tracer}
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+}
+
+func (span recordingSpan) End(outcome vmwarev1.SpanOutcome) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.tracer.outcomes = append(span.tracer.outcomes, outcome)
+}
+
+var _ = Describe(`VmwareV1 tracing`, func() {
+	var testServer *httptest.Server
+	var mutex sync.Mutex
+	var spanHeaders []string
+	var failures int
+	var tracer *recordingTracer
+	var vmwareService *vmwarev1.VmwareV1
+
+	BeforeEach(func() {
+		spanHeaders = nil
+		failures = 0
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			spanHeaders = append(spanHeaders, req.Header.Get("X-Test-Span"))
+			res.Header().Set("Content-type", "application/json")
+			if failures > 0 {
+				failures--
+				res.WriteHeader(503)
+				fmt.Fprint(res, `{"errors": [{"code": "unavailable", "message": "Try again later."}]}`)
+				return
+			}
+			res.WriteHeader(200)
+			fmt.Fprint(res, `{"id": "testCluster", "site_id": "testSite", "status": "ReadyToUse"}`)
+		}))
+
+		tracer = &recordingTracer{}
+		var err error
+		vmwareService, err = vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL + "/v1",
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		vmwareService.EnableTracing(tracer)
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Start a span per operation with the path parameters and send the returned request`, func() {
+		_, _, err := vmwareService.GetSpecificClusterInstance(vmwareService.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+		Expect(err).To(BeNil())
+		_, _, _ = vmwareService.ReplaceOrgAdminPassword(vmwareService.NewReplaceOrgAdminPasswordOptions("testSite"))
+		_, _, _ = vmwareService.GetVdc(vmwareService.NewGetVdcOptions("test/Vdc"))
+
+		Expect(tracer.operations).To(HaveLen(3))
+		Expect(tracer.operations[0].Operation).To(Equal("GetSpecificClusterInstance"))
+		Expect(tracer.operations[0].SiteID).To(Equal("testSite"))
+		Expect(tracer.operations[0].ClusterID).To(Equal("testCluster"))
+		Expect(tracer.operations[0].VdcID).To(BeEmpty())
+		Expect(tracer.operations[1].SiteID).To(Equal("testSite"))
+		Expect(tracer.operations[2].VdcID).To(Equal("test/Vdc"))
+		Expect(spanHeaders).To(Equal([]string{"GetSpecificClusterInstance", "ReplaceOrgAdminPassword", "GetVdc"}))
+
+		Expect(tracer.outcomes).To(HaveLen(3))
+		Expect(tracer.outcomes[0].Response.StatusCode).To(Equal(200))
+		Expect(tracer.outcomes[0].Retries).To(Equal(0))
+		Expect(tracer.outcomes[0].Err).To(BeNil())
+	})
+	It(`Count retries without replacing the transport of the HTTP client`, func() {
+		vmwareService.Service.DisableSSLVerification()
+		vmwareService.EnableRetries(2, 10*time.Millisecond)
+		failures = 1
+		_, _, err := vmwareService.GetSpecificClusterInstance(vmwareService.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+		Expect(err).To(BeNil())
+		Expect(tracer.outcomes[0].Retries).To(Equal(1))
+		Expect(vmwareService.Service.IsSSLDisabled()).To(BeTrue())
+
+		// A client set after tracing was enabled is counted too, and keeps its transport.
+		transport := &http.Transport{}
+		client := &http.Client{Transport: transport}
+		vmwareService.Service.SetHTTPClient(client)
+		vmwareService.EnableRetries(2, 10*time.Millisecond)
+		failures = 3
+		_, _, err = vmwareService.GetSpecificClusterInstance(vmwareService.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+		Expect(vmwarev1.IsRetryable(err)).To(BeTrue())
+		Expect(tracer.outcomes[1].Retries).To(Equal(2))
+		Expect(tracer.outcomes[1].Response.StatusCode).To(Equal(503))
+		Expect(tracer.outcomes[1].Err).ToNot(BeNil())
+		Expect(client.Transport).To(BeIdenticalTo(transport))
+	})
+	It(`Stop tracing when disabled`, func() {
+		vmwareService.DisableTracing()
+		_, _, err := vmwareService.GetSpecificClusterInstance(vmwareService.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+		Expect(err).To(BeNil())
+		Expect(tracer.operations).To(BeEmpty())
+		Expect(spanHeaders).To(Equal([]string{""}))
+	})
+})
@@ -0,0 +1,124 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// transactionIDKey is the context key of the transaction ID set with WithTransactionID.
+type transactionIDKey struct{}
+
+// TransactionIDOptions : Options for the transaction IDs generated by VmwareV1
+type TransactionIDOptions struct {
+	// Returns the transaction ID of a call that has none, or "" to send the call without one. Defaults to
+	// NewTransactionID, leaving the header unset when no random ID can be generated.
+	Generate func(ctx context.Context) string
+}
+
+// EnableTransactionIDs : Send an X-Global-Transaction-ID with every operation
+// The transaction ID of a call is, in order of precedence, the XGlobalTransactionID of its options (or an
+// X-Global-Transaction-ID entry of its Headers), the ID set on its context with WithTransactionID, or a new ID returned
+// by options.Generate. Without EnableTransactionIDs only the first two are sent.
+//
+// The ID used is returned by TransactionID for the DetailedResponse of the call, and is the TransactionID of an
+// *APIError, so support tickets can quote it.
+func (vmware *VmwareV1) EnableTransactionIDs(options *TransactionIDOptions) {
+	resolved := TransactionIDOptions{}
+	if options != nil {
+		resolved = *options
+	}
+	if resolved.Generate == nil {
+		resolved.Generate = func(context.Context) string {
+			transactionID, _ := NewTransactionID()
+			return transactionID
+		}
+	}
+	vmware.transactionIDs = &resolved
+}
+
+// DisableTransactionIDs : Stop generating transaction IDs
+// IDs set on the options or the context of a call are still sent.
+func (vmware *VmwareV1) DisableTransactionIDs() {
+	vmware.transactionIDs = nil
+}
+
+// WithTransactionID returns a copy of ctx carrying the transaction ID to send with the operations called with it.
+func WithTransactionID(ctx context.Context, transactionID string) context.Context {
+	return context.WithValue(ctx, transactionIDKey{}, transactionID)
+}
+
+// TransactionIDFromContext returns the transaction ID set on ctx with WithTransactionID, or "" when there is none.
+func TransactionIDFromContext(ctx context.Context) string {
+	transactionID, _ := ctx.Value(transactionIDKey{}).(string)
+	return transactionID
+}
+
+// NewTransactionID returns a new random transaction ID in the form of a version 4 UUID, or an error when the random
+// bytes cannot be read.
+func NewTransactionID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("reading random bytes: %s", err.Error())
+	}
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]), nil
+}
+
+// TransactionID returns the X-Global-Transaction-ID of the response of an operation, which is the ID echoed by the
+// service or, when the service does not echo it, the ID sent with the request. It returns "" when no ID was used, and
+// for responses answered from the cache of EnableCache, including calls that shared the response of an identical call
+// in flight, as they sent no request of their own.
+func TransactionID(response *core.DetailedResponse) string {
+	if response == nil {
+		return ""
+	}
+	return response.Headers.Get(transactionIDHeader)
+}
+
+// setTransactionID sets the transaction ID header of the request according to the precedence described on
+// EnableTransactionIDs and returns the ID, or "" when the request has none.
+func (vmware *VmwareV1) setTransactionID(request *http.Request) string {
+	if transactionID := requestTransactionID(request); transactionID != "" {
+		return transactionID
+	}
+	transactionID := TransactionIDFromContext(request.Context())
+	if transactionID == "" && vmware.transactionIDs != nil {
+		transactionID = vmware.transactionIDs.Generate(request.Context())
+	}
+	if transactionID != "" {
+		request.Header[transactionIDHeader] = []string{transactionID}
+	}
+	return transactionID
+}
+
+// recordTransactionID makes the transaction ID sent with the request available from the response.
+func recordTransactionID(response *core.DetailedResponse, transactionID string) {
+	if response == nil || transactionID == "" || response.Headers.Get(transactionIDHeader) != "" {
+		return
+	}
+	if response.Headers == nil {
+		response.Headers = make(http.Header)
+	}
+	response.Headers.Set(transactionIDHeader, transactionID)
+}
@@ -0,0 +1,169 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 transaction IDs`, func() {
+	var testServer *httptest.Server
+	var mutex sync.Mutex
+	var received []string
+	var echo string
+	var status int
+	var vmwareService *vmwarev1.VmwareV1
+
+	BeforeEach(func() {
+		received = nil
+		echo = ""
+		status = 200
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			Expect(len(req.Header["X-Global-Transaction-Id"])).To(BeNumerically("<=", 1))
+			received = append(received, req.Header.Get("X-Global-Transaction-ID"))
+			res.Header().Set("Content-type", "application/json")
+			if echo != "" {
+				res.Header().Set("X-Global-Transaction-ID", echo)
+			}
+			res.WriteHeader(status)
+			if status != 200 {
+				fmt.Fprint(res, `{"errors": [{"code": "not_found", "message": "Not found."}]}`)
+				return
+			}
+			fmt.Fprint(res, `{"vdcs": []}`)
+		}))
+		var err error
+		vmwareService, err = vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Send no transaction ID by default`, func() {
+		_, response, err := vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		Expect(received).To(Equal([]string{""}))
+		Expect(vmwarev1.TransactionID(response)).To(BeEmpty())
+	})
+	It(`Generate a new transaction ID per call`, func() {
+		vmwareService.EnableTransactionIDs(nil)
+		_, first, err := vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		_, second, err := vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+
+		Expect(received).To(HaveLen(2))
+		Expect(received[0]).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+		Expect(received[1]).ToNot(Equal(received[0]))
+		Expect(vmwarev1.TransactionID(first)).To(Equal(received[0]))
+		Expect(vmwarev1.TransactionID(second)).To(Equal(received[1]))
+	})
+	It(`Generate version 4 UUIDs`, func() {
+		transactionID, err := vmwarev1.NewTransactionID()
+		Expect(err).To(BeNil())
+		Expect(transactionID).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+	})
+	It(`Send no transaction ID when the generator returns none`, func() {
+		vmwareService.EnableTransactionIDs(&vmwarev1.TransactionIDOptions{
+			Generate: func(context.Context) string {
+				return ""
+			},
+		})
+		_, response, err := vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		Expect(received).To(Equal([]string{""}))
+		Expect(vmwarev1.TransactionID(response)).To(BeEmpty())
+	})
+	It(`Prefer the options, then the context, then the generator`, func() {
+		vmwareService.EnableTransactionIDs(&vmwarev1.TransactionIDOptions{
+			Generate: func(context.Context) string {
+				return "generated"
+			},
+		})
+		ctx := vmwarev1.WithTransactionID(context.Background(), "from-context")
+		Expect(vmwarev1.TransactionIDFromContext(ctx)).To(Equal("from-context"))
+
+		_, _, err := vmwareService.ListVdcsWithContext(ctx, vmwareService.NewListVdcsOptions().SetXGlobalTransactionID("from-options"))
+		Expect(err).To(BeNil())
+		_, _, err = vmwareService.ListVdcsWithContext(ctx, vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		_, _, err = vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		Expect(received).To(Equal([]string{"from-options", "from-context", "generated"}))
+
+		vmwareService.DisableTransactionIDs()
+		_, _, err = vmwareService.ListVdcsWithContext(ctx, vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		_, _, err = vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		Expect(received[3:]).To(Equal([]string{"from-context", ""}))
+	})
+	It(`Return the transaction ID echoed by the service`, func() {
+		echo = "from-service"
+		vmwareService.EnableTransactionIDs(nil)
+		_, response, err := vmwareService.ListVdcs(vmwareService.NewListVdcsOptions())
+		Expect(err).To(BeNil())
+		Expect(vmwarev1.TransactionID(response)).To(Equal("from-service"))
+	})
+	It(`Return the transaction ID on errors`, func() {
+		status = 404
+		vmwareService.EnableTransactionIDs(nil)
+		_, response, err := vmwareService.GetVdc(vmwareService.NewGetVdcOptions("testVdc"))
+		var apiErr *vmwarev1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.TransactionID).To(Equal(received[0]))
+		Expect(apiErr.TransactionID).ToNot(BeEmpty())
+		Expect(vmwarev1.TransactionID(response)).To(Equal(received[0]))
+		Expect(err.Error()).To(ContainSubstring(received[0]))
+	})
+	It(`Send the transaction ID of every VDC operation and ReplaceOrgAdminPassword`, func() {
+		directorSite := &vmwarev1.NewVDCDirectorSite{
+			ID:      core.StringPtr("testSite"),
+			Cluster: &vmwarev1.VDCDirectorSiteCluster{ID: core.StringPtr("testCluster")},
+		}
+		_, _, _ = vmwareService.CreateVdc(vmwareService.NewCreateVdcOptions("testVdc", directorSite).SetXGlobalTransactionID("create"))
+		_, _, _ = vmwareService.GetVdc(vmwareService.NewGetVdcOptions("testVdc").SetXGlobalTransactionID("get"))
+		_, _, _ = vmwareService.DeleteVdc(vmwareService.NewDeleteVdcOptions("testVdc").SetXGlobalTransactionID("delete"))
+		_, _, _ = vmwareService.ReplaceOrgAdminPassword(vmwareService.NewReplaceOrgAdminPasswordOptions("testSite").SetXGlobalTransactionID("password"))
+		Expect(received).To(Equal([]string{"create", "get", "delete", "password"}))
+	})
+	It(`Return the transaction ID of the options on errors`, func() {
+		status = 404
+		_, _, err := vmwareService.GetVdc(vmwareService.NewGetVdcOptions("testVdc").SetXGlobalTransactionID("from-options"))
+		var apiErr *vmwarev1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.TransactionID).To(Equal("from-options"))
+	})
+})
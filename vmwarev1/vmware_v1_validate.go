@@ -0,0 +1,179 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// MinimumClusterHostCount is the smallest number of hosts the service accepts in a cluster.
+const MinimumClusterHostCount = 2
+
+// OrderViolation : A problem found in a workload domain order
+type OrderViolation struct {
+	// The path of the offending field, such as "clusters[1].location".
+	Field string
+
+	// What is wrong with the field.
+	Message string
+}
+
+// String returns the field and message of the violation.
+func (violation OrderViolation) String() string {
+	return fmt.Sprintf("%s: %s", violation.Field, violation.Message)
+}
+
+// OrderValidationError is returned by ValidateWorkloadDomainOrder when the order has violations.
+type OrderValidationError struct {
+	// Every violation found in the order, in field order.
+	Violations []OrderViolation
+}
+
+// Error returns the error message.
+func (e *OrderValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.String()
+	}
+	return fmt.Sprintf("invalid workload domain order: %s", strings.Join(messages, "; "))
+}
+
+// ValidateWorkloadDomainOrder : Validate a workload domain order before creating it
+// Fetch the regions with GetRegions and the host profiles with ViewInstance, then check the order with
+// CheckWorkloadDomainOrder. An *OrderValidationError listing every violation is returned when the order is invalid.
+func (vmware *VmwareV1) ValidateWorkloadDomainOrder(ctx context.Context, createWorkloadDomainOptions *CreateWorkloadDomainOptions) (err error) {
+	err = core.ValidateNotNil(createWorkloadDomainOptions, "createWorkloadDomainOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	regions, _, err := vmware.GetRegionsWithContext(ctx, vmware.NewGetRegionsOptions())
+	if err != nil {
+		return
+	}
+	hostProfiles, _, err := vmware.ViewInstanceWithContext(ctx, vmware.NewViewInstanceOptions())
+	if err != nil {
+		return
+	}
+	if violations := CheckWorkloadDomainOrder(createWorkloadDomainOptions, regions, hostProfiles); len(violations) > 0 {
+		err = &OrderValidationError{Violations: violations}
+	}
+	return
+}
+
+// CheckWorkloadDomainOrder : Check a workload domain order against the regions and host profiles of the service
+// Every cluster must be deployed in a data center of regions, use a host profile of hostProfiles, have a unique name,
+// at least MinimumClusterHostCount hosts and a non-empty FileShares. All violations are returned; an empty result
+// means the order is valid.
+func CheckWorkloadDomainOrder(createWorkloadDomainOptions *CreateWorkloadDomainOptions, regions *DirectorSiteRegions, hostProfiles *ListHostProfiles) (violations []OrderViolation) {
+	violate := func(field string, format string, args ...interface{}) {
+		violations = append(violations, OrderViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	if createWorkloadDomainOptions == nil {
+		violate("", "the order is missing")
+		return
+	}
+	if core.StringNilMapper(createWorkloadDomainOptions.Name) == "" {
+		violate("name", "is required")
+	}
+	if core.StringNilMapper(createWorkloadDomainOptions.ResourceGroup) == "" {
+		violate("resource_group", "is required")
+	}
+	if len(createWorkloadDomainOptions.Clusters) == 0 {
+		violate("clusters", "at least one cluster is required")
+	}
+
+	datacenters := make(map[string]bool)
+	if regions != nil {
+		for _, region := range regions.DirectorSiteRegions {
+			for _, datacenter := range region.Datacenters {
+				datacenters[core.StringNilMapper(datacenter.Name)] = true
+			}
+		}
+	}
+	profiles := make(map[string]bool)
+	if hostProfiles != nil {
+		for _, profile := range hostProfiles.DirectorSiteHostProfiles {
+			profiles[core.StringNilMapper(profile.ProfileName)] = true
+		}
+	}
+
+	names := make(map[string]int)
+	for i, cluster := range createWorkloadDomainOptions.Clusters {
+		field := func(name string) string {
+			return fmt.Sprintf("clusters[%d].%s", i, name)
+		}
+
+		name := core.StringNilMapper(cluster.Name)
+		if name == "" {
+			violate(field("name"), "is required")
+		} else if first, ok := names[name]; ok {
+			violate(field("name"), "%q is already used by clusters[%d]", name, first)
+		} else {
+			names[name] = i
+		}
+
+		location := core.StringNilMapper(cluster.Location)
+		if location == "" {
+			violate(field("location"), "is required")
+		} else if !datacenters[location] {
+			violate(field("location"), "%q is not a supported data center; use one of %s", location, sortedKeys(datacenters))
+		}
+
+		hostProfile := core.StringNilMapper(cluster.HostProfile)
+		if hostProfile == "" {
+			violate(field("host_profile"), "is required")
+		} else if !profiles[hostProfile] {
+			violate(field("host_profile"), "%q is not a supported host profile; use one of %s", hostProfile, sortedKeys(profiles))
+		}
+
+		if cluster.HostCount == nil {
+			violate(field("host_count"), "is required")
+		} else if *cluster.HostCount < MinimumClusterHostCount {
+			violate(field("host_count"), "must be at least %d, got %d", MinimumClusterHostCount, *cluster.HostCount)
+		}
+
+		empty := true
+		for _, share := range fileShareTiers(cluster.FileShares) {
+			if share.size == nil {
+				continue
+			}
+			if *share.size < 0 {
+				violate(field("file_shares."+share.tier), "must not be negative, got %d", *share.size)
+			} else if *share.size > 0 {
+				empty = false
+			}
+		}
+		if empty {
+			violate(field("file_shares"), "at least one storage tier must have a size")
+		}
+	}
+	return
+}
+
+func sortedKeys(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,99 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 workload domain order validation`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(nil)
+		var err error
+		vmwareService, err = server.NewClient()
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	clusterOrder := func(name string, location string, hostCount int64, fileShares *vmwarev1.FileShares, hostProfile string) vmwarev1.ClusterOrderInfo {
+		cluster, err := vmwareService.NewClusterOrderInfo(name, location, hostCount, fileShares, hostProfile)
+		Expect(err).To(BeNil())
+		return *cluster
+	}
+	storage := &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(24000)}
+
+	It(`Accept a valid order`, func() {
+		order := vmwareService.NewCreateWorkloadDomainOptions("testSite", "Default", []vmwarev1.ClusterOrderInfo{
+			clusterOrder("cluster1", "dal10", 2, storage, vmwarev1fake.HostProfile192GB),
+			clusterOrder("cluster2", "fra02", 3, storage, vmwarev1fake.HostProfile384GB),
+		})
+		Expect(vmwareService.ValidateWorkloadDomainOrder(context.Background(), order)).To(Succeed())
+		Expect(server.RequestCount("GetRegions")).To(Equal(1))
+		Expect(server.RequestCount("ViewInstance")).To(Equal(1))
+	})
+	It(`Return every violation at once`, func() {
+		order := vmwareService.NewCreateWorkloadDomainOptions("testSite", "Default", []vmwarev1.ClusterOrderInfo{
+			clusterOrder("cluster1", "dal99", 1, storage, vmwarev1fake.HostProfile192GB),
+			clusterOrder("cluster1", "dal10", 2, &vmwarev1.FileShares{}, "BM_UNKNOWN"),
+		})
+		err := vmwareService.ValidateWorkloadDomainOrder(context.Background(), order)
+		var validationErr *vmwarev1.OrderValidationError
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+
+		fields := []string{}
+		for _, violation := range validationErr.Violations {
+			fields = append(fields, violation.Field)
+		}
+		Expect(fields).To(Equal([]string{
+			"clusters[0].location",
+			"clusters[0].host_count",
+			"clusters[1].name",
+			"clusters[1].host_profile",
+			"clusters[1].file_shares",
+		}))
+		Expect(validationErr.Violations[0].Message).To(ContainSubstring("dal10, dal12, dal13, fra02, fra04, fra05"))
+		Expect(validationErr.Violations[2].Message).To(ContainSubstring("clusters[0]"))
+		Expect(err.Error()).To(ContainSubstring("clusters[1].file_shares: at least one storage tier must have a size"))
+	})
+	It(`Return service errors of the reference data`, func() {
+		server.InjectFault("GetRegions", vmwarev1fake.Fault{StatusCode: 503, Times: 1})
+
+		order := vmwareService.NewCreateWorkloadDomainOptions("testSite", "Default", nil)
+		err := vmwareService.ValidateWorkloadDomainOrder(context.Background(), order)
+		Expect(vmwarev1.IsRetryable(err)).To(BeTrue())
+	})
+	It(`Check an order offline`, func() {
+		violations := vmwarev1.CheckWorkloadDomainOrder(&vmwarev1.CreateWorkloadDomainOptions{}, nil, nil)
+		Expect(violations).To(Equal([]vmwarev1.OrderViolation{
+			{Field: "name", Message: "is required"},
+			{Field: "resource_group", Message: "is required"},
+			{Field: "clusters", Message: "at least one cluster is required"},
+		}))
+		Expect(vmwarev1.CheckWorkloadDomainOrder(nil, nil, nil)).To(HaveLen(1))
+	})
+})
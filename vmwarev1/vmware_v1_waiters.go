@@ -0,0 +1,328 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Default values used by the waiters when the corresponding WaitOptions field is not set.
+const (
+	DefaultWaitPollInterval    = 30 * time.Second
+	DefaultWaitMaxPollInterval = 5 * time.Minute
+	DefaultWaitTimeout         = 6 * time.Hour
+)
+
+// WaitOptions : Options that control how a waiter polls the service for a resource status.
+type WaitOptions struct {
+	// The delay before the first re-poll of the resource. Defaults to DefaultWaitPollInterval.
+	PollInterval time.Duration
+
+	// The upper bound for the delay between two polls. Defaults to DefaultWaitMaxPollInterval.
+	MaxPollInterval time.Duration
+
+	// The factor applied to the poll interval after every poll. Values less than or equal to 1 disable the
+	// exponential backoff and the resource is polled every PollInterval.
+	BackoffMultiplier float64
+
+	// The overall time to wait for the resource. Defaults to DefaultWaitTimeout. The wait also ends when the
+	// context passed to the waiter is done.
+	Timeout time.Duration
+
+	// The statuses that end the wait successfully. Each waiter documents its default.
+	TargetStatuses []string
+
+	// The statuses that end the wait with an error. Each waiter documents its default.
+	FailedStatuses []string
+
+	// Invoked every time the waiter observes a status that differs from the previous poll, including the first poll.
+	OnStatusChange func(transition StatusTransition)
+}
+
+// StatusTransition : A change of status observed by a waiter.
+type StatusTransition struct {
+	// The kind of resource being waited on, such as "director site".
+	Resource string
+
+	// The ID of the resource being waited on.
+	ID string
+
+	// The status observed on the previous poll. Empty on the first poll.
+	From string
+
+	// The status observed on the current poll.
+	To string
+
+	// The number of polls made so far, starting at 1.
+	Attempt int
+
+	// The time elapsed since the wait started.
+	Elapsed time.Duration
+}
+
+// UnexpectedStatusError is returned by a waiter when the resource reaches one of the failed statuses.
+type UnexpectedStatusError struct {
+	// The kind of resource being waited on, such as "director site".
+	Resource string
+
+	// The ID of the resource being waited on.
+	ID string
+
+	// The failed status that was reached.
+	Status string
+}
+
+// Error returns the error message.
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("%s %s reached failed status %q", e.Resource, e.ID, e.Status)
+}
+
+// WaitTimeoutError is returned by a waiter when the resource does not reach a target status in time.
+type WaitTimeoutError struct {
+	// The kind of resource being waited on, such as "director site".
+	Resource string
+
+	// The ID of the resource being waited on.
+	ID string
+
+	// The statuses the waiter was waiting for.
+	TargetStatuses []string
+
+	// The last status observed before the wait ended. Empty when the resource was never read.
+	LastStatus string
+
+	// The error that ended the wait, usually context.DeadlineExceeded or context.Canceled.
+	Err error
+}
+
+// Error returns the error message.
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for %s %s to reach %s (last status %q): %s",
+		e.Resource, e.ID, strings.Join(e.TargetStatuses, ", "), e.LastStatus, e.Err)
+}
+
+// Unwrap returns the context error that ended the wait.
+func (e *WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForDirectorSite : Wait for a director site instance to reach a target status
+// Poll GetSpecificWorkloadDomainInstance until the director site reaches one of opts.TargetStatuses, which defaults to
+// DirectorSite_Status_Readytouse. When neither TargetStatuses nor FailedStatuses is set, DirectorSite_Status_Deleting
+// and DirectorSite_Status_Deleted are treated as failed statuses. The last observed director site is returned together
+// with any error.
+func (vmware *VmwareV1) WaitForDirectorSite(ctx context.Context, siteID string, opts *WaitOptions) (result *DirectorSite, err error) {
+	if siteID == "" {
+		err = fmt.Errorf("siteID cannot be empty")
+		return
+	}
+	opts = opts.withDefaults(
+		[]string{DirectorSite_Status_Readytouse},
+		[]string{DirectorSite_Status_Deleting, DirectorSite_Status_Deleted},
+	)
+
+	getOptions := vmware.NewGetSpecificWorkloadDomainInstanceOptions(siteID)
+	err = opts.poll(ctx, "director site", siteID, func(ctx context.Context) (status string, err error) {
+		site, _, err := vmware.GetSpecificWorkloadDomainInstanceWithContext(ctx, getOptions)
+		if err != nil {
+			return
+		}
+		result = site
+		status = core.StringNilMapper(site.Status)
+		return
+	}, nil)
+	return
+}
+
+// VDCFailedError is returned by WaitForVdc when the Virtual Data Center reaches VDC_Status_Failed. It carries every
+// entry of VDC.Errors reported by the service.
+type VDCFailedError struct {
+	// The ID of the Virtual Data Center.
+	VdcID string
+
+	// The errors reported by the service for the Virtual Data Center.
+	Errors []Error
+}
+
+// Error returns the error message.
+func (e *VDCFailedError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("VDC %s failed without reporting errors", e.VdcID)
+	}
+	messages := make([]string, len(e.Errors))
+	for i := range e.Errors {
+		messages[i] = e.Errors[i].Error()
+	}
+	return fmt.Sprintf("VDC %s failed: %s", e.VdcID, strings.Join(messages, "; "))
+}
+
+// As finds the first error reported by the service for the Virtual Data Center that matches target, so errors.As can
+// reach every entry of Errors.
+func (e *VDCFailedError) As(target interface{}) bool {
+	return asServiceError(e.Errors, target)
+}
+
+// Is reports whether any error reported by the service for the Virtual Data Center matches target, so errors.Is can
+// reach every entry of Errors.
+func (e *VDCFailedError) Is(target error) bool {
+	return isServiceError(e.Errors, target)
+}
+
+// WaitForVdc : Wait for a Virtual Data Center to reach a target status
+// Poll GetVdc until the Virtual Data Center reaches one of opts.TargetStatuses, which defaults to VDC_Status_Readytouse
+// and VDC_Status_Deleted. When neither TargetStatuses nor FailedStatuses is set, VDC_Status_Failed is treated as a
+// failed status and the returned error is a *VDCFailedError wrapping every entry of VDC.Errors. A 404 response is
+// treated as VDC_Status_Deleted, so waiting after DeleteVdc succeeds once the Virtual Data Center is gone; the result
+// is nil then. Otherwise the last observed Virtual Data Center is returned together with any error.
+func (vmware *VmwareV1) WaitForVdc(ctx context.Context, vdcID string, opts *WaitOptions) (result *VDC, err error) {
+	if vdcID == "" {
+		err = fmt.Errorf("vdcID cannot be empty")
+		return
+	}
+	opts = opts.withDefaults(
+		[]string{VDC_Status_Readytouse, VDC_Status_Deleted},
+		[]string{VDC_Status_Failed},
+	)
+
+	getOptions := vmware.NewGetVdcOptions(vdcID)
+	err = opts.poll(ctx, "VDC", vdcID, func(ctx context.Context) (status string, err error) {
+		vdc, _, err := vmware.GetVdcWithContext(ctx, getOptions)
+		if err != nil {
+			if IsNotFound(err) && containsStatus(opts.TargetStatuses, VDC_Status_Deleted) {
+				result = nil
+				return VDC_Status_Deleted, nil
+			}
+			return
+		}
+		result = vdc
+		status = core.StringNilMapper(vdc.Status)
+		return
+	}, nil)
+
+	var statusErr *UnexpectedStatusError
+	if errors.As(err, &statusErr) && statusErr.Status == VDC_Status_Failed && result != nil {
+		err = &VDCFailedError{VdcID: vdcID, Errors: result.Errors}
+	}
+	return
+}
+
+// withDefaults returns a copy of the options with unset fields replaced by their defaults. The default failed
+// statuses only apply when the caller did not choose its own target statuses.
+func (opts *WaitOptions) withDefaults(targetStatuses []string, failedStatuses []string) *WaitOptions {
+	resolved := WaitOptions{}
+	if opts != nil {
+		resolved = *opts
+	}
+	if resolved.PollInterval <= 0 {
+		resolved.PollInterval = DefaultWaitPollInterval
+	}
+	if resolved.MaxPollInterval <= 0 {
+		resolved.MaxPollInterval = DefaultWaitMaxPollInterval
+	}
+	if resolved.MaxPollInterval < resolved.PollInterval {
+		resolved.MaxPollInterval = resolved.PollInterval
+	}
+	if resolved.Timeout <= 0 {
+		resolved.Timeout = DefaultWaitTimeout
+	}
+	if len(resolved.TargetStatuses) == 0 {
+		resolved.TargetStatuses = targetStatuses
+		if len(resolved.FailedStatuses) == 0 {
+			resolved.FailedStatuses = failedStatuses
+		}
+	}
+	return &resolved
+}
+
+// poll invokes fetch until the returned status is a target or failed status, fetch fails, or the wait times out.
+// When settled is set, a target status only ends the wait once settled also reports true. Status transitions are
+// reported to OnStatusChange.
+func (opts *WaitOptions) poll(ctx context.Context, resource string, id string, fetch func(ctx context.Context) (status string, err error), settled func() bool) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	interval := opts.PollInterval
+	lastStatus := ""
+	for attempt := 1; ; attempt++ {
+		status, err := fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return opts.timeoutError(resource, id, lastStatus, ctx.Err())
+			}
+			return err
+		}
+		if attempt == 1 || status != lastStatus {
+			if opts.OnStatusChange != nil {
+				opts.OnStatusChange(StatusTransition{
+					Resource: resource,
+					ID:       id,
+					From:     lastStatus,
+					To:       status,
+					Attempt:  attempt,
+					Elapsed:  time.Since(start),
+				})
+			}
+			lastStatus = status
+		}
+		if containsStatus(opts.TargetStatuses, status) && (settled == nil || settled()) {
+			return nil
+		}
+		if containsStatus(opts.FailedStatuses, status) {
+			return &UnexpectedStatusError{Resource: resource, ID: id, Status: status}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return opts.timeoutError(resource, id, lastStatus, ctx.Err())
+		case <-timer.C:
+		}
+		if opts.BackoffMultiplier > 1 {
+			interval = time.Duration(float64(interval) * opts.BackoffMultiplier)
+			if interval > opts.MaxPollInterval {
+				interval = opts.MaxPollInterval
+			}
+		}
+	}
+}
+
+func (opts *WaitOptions) timeoutError(resource string, id string, lastStatus string, err error) error {
+	return &WaitTimeoutError{
+		Resource:       resource,
+		ID:             id,
+		TargetStatuses: opts.TargetStatuses,
+		LastStatus:     lastStatus,
+		Err:            err,
+	}
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 waiters`, func() {
+	var testServer *httptest.Server
+	fastWait := func() *vmwarev1.WaitOptions {
+		return &vmwarev1.WaitOptions{
+			PollInterval:      time.Millisecond,
+			MaxPollInterval:   4 * time.Millisecond,
+			BackoffMultiplier: 2,
+			Timeout:           5 * time.Second,
+		}
+	}
+	newService := func() *vmwarev1.VmwareV1 {
+		vmwareService, serviceErr := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		return vmwareService
+	}
+
+	Describe(`WaitForDirectorSite(ctx context.Context, siteID string, opts *WaitOptions)`, func() {
+		var mutex sync.Mutex
+		var statuses []string
+		var polls int
+
+		BeforeEach(func() {
+			polls = 0
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(req.URL.EscapedPath()).To(Equal("/director_sites/testString"))
+				Expect(req.Method).To(Equal("GET"))
+
+				mutex.Lock()
+				status := statuses[polls]
+				if polls < len(statuses)-1 {
+					polls++
+				}
+				mutex.Unlock()
+
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `{"id": "testString", "name": "Name", "status": "%s"}`, status)
+			}))
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+		It(`Wait until the director site is ready to use`, func() {
+			statuses = []string{"Creating", "Creating", "Updating", "ReadyToUse"}
+			var transitions []vmwarev1.StatusTransition
+			opts := fastWait()
+			opts.OnStatusChange = func(transition vmwarev1.StatusTransition) {
+				transitions = append(transitions, transition)
+			}
+
+			site, err := newService().WaitForDirectorSite(context.Background(), "testString", opts)
+			Expect(err).To(BeNil())
+			Expect(site).ToNot(BeNil())
+			Expect(*site.Status).To(Equal(vmwarev1.DirectorSite_Status_Readytouse))
+			Expect(transitions).To(HaveLen(3))
+			Expect(transitions[0].From).To(Equal(""))
+			Expect(transitions[0].To).To(Equal("Creating"))
+			Expect(transitions[1].From).To(Equal("Creating"))
+			Expect(transitions[1].To).To(Equal("Updating"))
+			Expect(transitions[2].To).To(Equal("ReadyToUse"))
+			Expect(transitions[2].Attempt).To(Equal(4))
+			Expect(transitions[2].Resource).To(Equal("director site"))
+		})
+		It(`Return an error when the director site reaches a failed status`, func() {
+			statuses = []string{"Creating", "Deleting"}
+
+			site, err := newService().WaitForDirectorSite(context.Background(), "testString", fastWait())
+			Expect(err).ToNot(BeNil())
+			var statusErr *vmwarev1.UnexpectedStatusError
+			Expect(errors.As(err, &statusErr)).To(BeTrue())
+			Expect(statusErr.Status).To(Equal(vmwarev1.DirectorSite_Status_Deleting))
+			Expect(*site.Status).To(Equal(vmwarev1.DirectorSite_Status_Deleting))
+		})
+		It(`Wait for custom target statuses`, func() {
+			statuses = []string{"Deleting", "Deleted"}
+			opts := fastWait()
+			opts.TargetStatuses = []string{vmwarev1.DirectorSite_Status_Deleted}
+
+			site, err := newService().WaitForDirectorSite(context.Background(), "testString", opts)
+			Expect(err).To(BeNil())
+			Expect(*site.Status).To(Equal(vmwarev1.DirectorSite_Status_Deleted))
+		})
+		It(`Return a timeout error when the director site never becomes ready`, func() {
+			statuses = []string{"Creating"}
+			opts := fastWait()
+			opts.Timeout = 20 * time.Millisecond
+
+			_, err := newService().WaitForDirectorSite(context.Background(), "testString", opts)
+			Expect(err).ToNot(BeNil())
+			var timeoutErr *vmwarev1.WaitTimeoutError
+			Expect(errors.As(err, &timeoutErr)).To(BeTrue())
+			Expect(timeoutErr.LastStatus).To(Equal(vmwarev1.DirectorSite_Status_Creating))
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		})
+		It(`Invoke WaitForDirectorSite with error: empty site ID`, func() {
+			_, err := newService().WaitForDirectorSite(context.Background(), "", nil)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	Describe(`WaitForVdc(ctx context.Context, vdcID string, opts *WaitOptions)`, func() {
+		var mutex sync.Mutex
+		var bodies []string
+		var polls int
+
+		BeforeEach(func() {
+			polls = 0
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(req.URL.EscapedPath()).To(Equal("/vdcs/testString"))
+				Expect(req.Method).To(Equal("GET"))
+
+				mutex.Lock()
+				body := bodies[polls]
+				if polls < len(bodies)-1 {
+					polls++
+				}
+				mutex.Unlock()
+
+				res.Header().Set("Content-type", "application/json")
+				if body == "" {
+					res.WriteHeader(404)
+					fmt.Fprintf(res, "%s", `{"errors": [{"code": "not_found", "message": "VDC not found"}]}`)
+					return
+				}
+				res.WriteHeader(200)
+				fmt.Fprintf(res, "%s", body)
+			}))
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+		It(`Wait until the VDC is ready to use`, func() {
+			bodies = []string{
+				`{"id": "testString", "status": "Creating", "errors": []}`,
+				`{"id": "testString", "status": "ReadyToUse", "errors": []}`,
+			}
+
+			vdc, err := newService().WaitForVdc(context.Background(), "testString", fastWait())
+			Expect(err).To(BeNil())
+			Expect(*vdc.Status).To(Equal(vmwarev1.VDC_Status_Readytouse))
+		})
+		It(`Return every VDC error when the VDC fails`, func() {
+			bodies = []string{
+				`{"id": "testString", "status": "Creating", "errors": []}`,
+				`{"id": "testString", "status": "Failed", "errors": [{"code": "capacity", "message": "Not enough capacity", "more_info": "https://cloud.ibm.com/docs"}, {"code": "edge", "message": "Edge deployment failed"}]}`,
+			}
+
+			vdc, err := newService().WaitForVdc(context.Background(), "testString", fastWait())
+			Expect(err).ToNot(BeNil())
+			Expect(*vdc.Status).To(Equal(vmwarev1.VDC_Status_Failed))
+
+			var failedErr *vmwarev1.VDCFailedError
+			Expect(errors.As(err, &failedErr)).To(BeTrue())
+			Expect(failedErr.VdcID).To(Equal("testString"))
+			Expect(failedErr.Errors).To(HaveLen(2))
+			var serviceErr *vmwarev1.Error
+			Expect(errors.As(err, &serviceErr)).To(BeTrue())
+			Expect(*serviceErr.Code).To(Equal("capacity"))
+			Expect(errors.Is(err, &vmwarev1.Error{Code: core.StringPtr("edge")})).To(BeTrue())
+			Expect(errors.Is(err, &vmwarev1.Error{Code: core.StringPtr("quota")})).To(BeFalse())
+			Expect(err.Error()).To(ContainSubstring("capacity: Not enough capacity (https://cloud.ibm.com/docs)"))
+			Expect(err.Error()).To(ContainSubstring("edge: Edge deployment failed"))
+		})
+		It(`Treat a 404 response as a deleted VDC`, func() {
+			bodies = []string{
+				`{"id": "testString", "status": "Deleting", "errors": []}`,
+				"",
+			}
+			var transitions []vmwarev1.StatusTransition
+			opts := fastWait()
+			opts.OnStatusChange = func(transition vmwarev1.StatusTransition) {
+				transitions = append(transitions, transition)
+			}
+
+			vdc, err := newService().WaitForVdc(context.Background(), "testString", opts)
+			Expect(err).To(BeNil())
+			Expect(vdc).To(BeNil())
+			Expect(transitions).To(HaveLen(2))
+			Expect(transitions[1].To).To(Equal(vmwarev1.VDC_Status_Deleted))
+		})
+		It(`Return the 404 error when not waiting for deletion`, func() {
+			bodies = []string{""}
+			opts := fastWait()
+			opts.TargetStatuses = []string{vmwarev1.VDC_Status_Readytouse}
+
+			_, err := newService().WaitForVdc(context.Background(), "testString", opts)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("VDC not found"))
+		})
+	})
+})
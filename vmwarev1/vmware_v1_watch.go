@@ -0,0 +1,380 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultWatchPollInterval is the delay between two snapshots of Watch when WatchOptions.PollInterval is not set.
+const DefaultWatchPollInterval = 30 * time.Second
+
+// Resources observed by Watch.
+const (
+	WatchResourceDirectorSite = "director site"
+	WatchResourceCluster      = "cluster"
+	WatchResourceVDC          = "VDC"
+)
+
+// WatchEventType : The kind of change reported by a WatchEvent
+type WatchEventType string
+
+// Kinds of WatchEvent.
+const (
+	// The resource appeared, or existed when the watch started.
+	WatchEventAdded WatchEventType = "Added"
+
+	// The resource changed between two snapshots.
+	WatchEventModified WatchEventType = "Modified"
+
+	// The resource disappeared.
+	WatchEventDeleted WatchEventType = "Deleted"
+
+	// A snapshot could not be taken. The watch goes on with the next poll.
+	WatchEventError WatchEventType = "Error"
+)
+
+// WatchOptions : Options that control how Watch observes the service
+type WatchOptions struct {
+	// The delay between two snapshots. Defaults to DefaultWatchPollInterval.
+	PollInterval time.Duration
+
+	// Only watch this director site and its clusters and Virtual Data Centers. Empty watches every director site.
+	SiteID string
+
+	// The resources to watch, among WatchResourceDirectorSite, WatchResourceCluster and WatchResourceVDC. Empty
+	// watches all of them.
+	Resources []string
+
+	// Do not report the resources that exist when the watch starts as added.
+	IgnoreExisting bool
+}
+
+// WatchEvent : A change observed by Watch
+// Old and New hold the resource before and after the change and are of the type of the resource: *DirectorSite,
+// *Cluster or *VDC. Old is nil for WatchEventAdded and New is nil for WatchEventDeleted.
+type WatchEvent struct {
+	// The kind of change.
+	Type WatchEventType
+
+	// The kind of resource that changed, such as WatchResourceCluster. Empty for WatchEventError.
+	Resource string
+
+	// The ID of the resource that changed.
+	ID string
+
+	// The ID of the director site of the resource. For director sites, the same as ID.
+	SiteID string
+
+	// The director site before and after the change, for director site events.
+	OldDirectorSite *DirectorSite
+	NewDirectorSite *DirectorSite
+
+	// The cluster before and after the change, for cluster events.
+	OldCluster *Cluster
+	NewCluster *Cluster
+
+	// The Virtual Data Center before and after the change, for VDC events.
+	OldVDC *VDC
+	NewVDC *VDC
+
+	// The error that prevented the snapshot, for WatchEventError.
+	Err error
+
+	// When the change was observed.
+	Time time.Time
+}
+
+// String describes the event on a single line.
+func (event WatchEvent) String() string {
+	if event.Type == WatchEventError {
+		return fmt.Sprintf("%s: %s", event.Type, event.Err)
+	}
+	return fmt.Sprintf("%s %s %s", event.Type, event.Resource, event.ID)
+}
+
+// Watch : Watch director sites, clusters and Virtual Data Centers for changes
+// Take a snapshot with ListWorkloadDomainInstances, ListClusterInstances and ListVdcs every opts.PollInterval, and
+// send an event on the returned channel for every resource that was added, modified or deleted since the previous
+// snapshot. Within a snapshot, director site events come before cluster events, which come before VDC events. When a
+// list call fails a WatchEventError is sent and the resources it covers are compared again on the next poll; until a
+// first snapshot succeeds entirely, no other event is sent. The channel is closed when ctx is done.
+func (vmware *VmwareV1) Watch(ctx context.Context, opts *WatchOptions) (<-chan WatchEvent, error) {
+	watcher := &watcher{vmware: vmware, resources: make(map[string]bool)}
+	if opts != nil {
+		watcher.options = *opts
+	}
+	if watcher.options.PollInterval <= 0 {
+		watcher.options.PollInterval = DefaultWatchPollInterval
+	}
+	for _, resource := range watcher.options.Resources {
+		switch resource {
+		case WatchResourceDirectorSite, WatchResourceCluster, WatchResourceVDC:
+			watcher.resources[resource] = true
+		default:
+			return nil, fmt.Errorf("unknown watch resource %q", resource)
+		}
+	}
+	if len(watcher.resources) == 0 {
+		watcher.resources = map[string]bool{WatchResourceDirectorSite: true, WatchResourceCluster: true, WatchResourceVDC: true}
+	}
+
+	events := make(chan WatchEvent)
+	go watcher.run(ctx, events)
+	return events, nil
+}
+
+// watchSnapshot : The resources observed by a single poll of Watch, keyed by ID
+type watchSnapshot struct {
+	sites    map[string]*DirectorSite
+	clusters map[string]*Cluster
+	vdcs     map[string]*VDC
+}
+
+// watcher : The state of a running Watch
+type watcher struct {
+	vmware    *VmwareV1
+	options   WatchOptions
+	resources map[string]bool
+	previous  *watchSnapshot
+}
+
+func (watcher *watcher) run(ctx context.Context, events chan<- WatchEvent) {
+	defer close(events)
+	for {
+		if !watcher.poll(ctx, events) {
+			return
+		}
+		timer := time.NewTimer(watcher.options.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// poll takes a snapshot and sends its differences with the previous one. It returns false when ctx is done.
+func (watcher *watcher) poll(ctx context.Context, events chan<- WatchEvent) bool {
+	now := time.Now()
+	send := func(event WatchEvent) bool {
+		event.Time = now
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	current, errs := watcher.snapshot(ctx)
+	if ctx.Err() != nil {
+		return false
+	}
+	for _, err := range errs {
+		if !send(WatchEvent{Type: WatchEventError, Err: err}) {
+			return false
+		}
+	}
+
+	previous := watcher.previous
+	if previous == nil {
+		if len(errs) > 0 {
+			// Keep the first snapshot complete, so IgnoreExisting does not skip resources that were not listed.
+			return true
+		}
+		watcher.previous = current
+		if watcher.options.IgnoreExisting {
+			return true
+		}
+		previous = &watchSnapshot{}
+	}
+	watcher.previous = current
+	for _, event := range diffSnapshots(previous, current) {
+		if !send(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshot lists the watched resources. Resources whose list call fails are copied from the previous snapshot, so a
+// failure is not reported as a deletion.
+func (watcher *watcher) snapshot(ctx context.Context) (current *watchSnapshot, errs []error) {
+	previous := watcher.previous
+	if previous == nil {
+		previous = &watchSnapshot{}
+	}
+	current = &watchSnapshot{
+		sites:    make(map[string]*DirectorSite),
+		clusters: make(map[string]*Cluster),
+		vdcs:     make(map[string]*VDC),
+	}
+
+	if watcher.resources[WatchResourceDirectorSite] || watcher.resources[WatchResourceCluster] {
+		var sites []DirectorSite
+		var err error
+		if watcher.options.SiteID != "" {
+			var site *DirectorSite
+			site, _, err = watcher.vmware.GetSpecificWorkloadDomainInstanceWithContext(ctx, watcher.vmware.NewGetSpecificWorkloadDomainInstanceOptions(watcher.options.SiteID))
+			if err == nil {
+				sites = append(sites, *site)
+			} else if IsNotFound(err) {
+				err = nil
+			}
+		} else {
+			var list *ListDirectorSites
+			list, _, err = watcher.vmware.ListWorkloadDomainInstancesWithContext(ctx, watcher.vmware.NewListWorkloadDomainInstancesOptions())
+			if err == nil {
+				sites = list.DirectorSites
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+			current.sites, current.clusters = previous.sites, previous.clusters
+		} else {
+			for i := range sites {
+				current.sites[core.StringNilMapper(sites[i].ID)] = &sites[i]
+			}
+			if watcher.resources[WatchResourceCluster] {
+				errs = append(errs, watcher.listClusters(ctx, current, previous)...)
+			}
+		}
+		if !watcher.resources[WatchResourceDirectorSite] {
+			current.sites = nil
+		}
+	}
+
+	if watcher.resources[WatchResourceVDC] {
+		list, _, err := watcher.vmware.ListVdcsWithContext(ctx, watcher.vmware.NewListVdcsOptions())
+		if err != nil {
+			errs = append(errs, err)
+			current.vdcs = previous.vdcs
+		} else {
+			for i := range list.Vdcs {
+				vdc := &list.Vdcs[i]
+				if watcher.options.SiteID != "" && (vdc.DirectorSite == nil || core.StringNilMapper(vdc.DirectorSite.ID) != watcher.options.SiteID) {
+					continue
+				}
+				current.vdcs[core.StringNilMapper(vdc.ID)] = vdc
+			}
+		}
+	}
+	return
+}
+
+// listClusters lists the clusters of every director site of the current snapshot.
+func (watcher *watcher) listClusters(ctx context.Context, current *watchSnapshot, previous *watchSnapshot) (errs []error) {
+	for siteID := range current.sites {
+		list, _, err := watcher.vmware.ListClusterInstancesWithContext(ctx, watcher.vmware.NewListClusterInstancesOptions(siteID))
+		if IsNotFound(err) {
+			// The director site went away since it was listed.
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			for clusterID, cluster := range previous.clusters {
+				if core.StringNilMapper(cluster.SiteID) == siteID {
+					current.clusters[clusterID] = cluster
+				}
+			}
+			continue
+		}
+		for i := range list.Clusters {
+			cluster := &list.Clusters[i]
+			if cluster.SiteID == nil {
+				cluster.SiteID = core.StringPtr(siteID)
+			}
+			current.clusters[core.StringNilMapper(cluster.ID)] = cluster
+		}
+	}
+	return
+}
+
+// diffSnapshots returns the events that turn previous into current, ordered by resource kind and then by ID.
+func diffSnapshots(previous *watchSnapshot, current *watchSnapshot) (events []WatchEvent) {
+	for _, id := range unionKeys(previous.sites, current.sites) {
+		before, after := previous.sites[id], current.sites[id]
+		if eventType, changed := changeType(before, after); changed {
+			events = append(events, WatchEvent{Type: eventType, Resource: WatchResourceDirectorSite, ID: id, SiteID: id, OldDirectorSite: before, NewDirectorSite: after})
+		}
+	}
+	for _, id := range unionKeys(previous.clusters, current.clusters) {
+		before, after := previous.clusters[id], current.clusters[id]
+		if eventType, changed := changeType(before, after); changed {
+			siteID := ""
+			if after != nil {
+				siteID = core.StringNilMapper(after.SiteID)
+			} else {
+				siteID = core.StringNilMapper(before.SiteID)
+			}
+			events = append(events, WatchEvent{Type: eventType, Resource: WatchResourceCluster, ID: id, SiteID: siteID, OldCluster: before, NewCluster: after})
+		}
+	}
+	for _, id := range unionKeys(previous.vdcs, current.vdcs) {
+		before, after := previous.vdcs[id], current.vdcs[id]
+		if eventType, changed := changeType(before, after); changed {
+			vdc := after
+			if vdc == nil {
+				vdc = before
+			}
+			siteID := ""
+			if vdc.DirectorSite != nil {
+				siteID = core.StringNilMapper(vdc.DirectorSite.ID)
+			}
+			events = append(events, WatchEvent{Type: eventType, Resource: WatchResourceVDC, ID: id, SiteID: siteID, OldVDC: before, NewVDC: after})
+		}
+	}
+	return
+}
+
+// changeType compares two versions of a resource, either of which may be nil.
+func changeType[T any](before *T, after *T) (WatchEventType, bool) {
+	switch {
+	case before == nil && after == nil:
+		return "", false
+	case before == nil:
+		return WatchEventAdded, true
+	case after == nil:
+		return WatchEventDeleted, true
+	case !reflect.DeepEqual(before, after):
+		return WatchEventModified, true
+	}
+	return "", false
+}
+
+// unionKeys returns the keys of both maps, sorted.
+func unionKeys[T any](a map[string]*T, b map[string]*T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`VmwareV1 watch`, func() {
+	var server *vmwarev1fake.Server
+	var vmwareService *vmwarev1.VmwareV1
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var site *vmwarev1.DirectorSite
+
+	BeforeEach(func() {
+		server = vmwarev1fake.NewServer(&vmwarev1fake.ServerOptions{TransitionDelay: time.Hour})
+		var err error
+		vmwareService, err = server.NewClient()
+		Expect(err).To(BeNil())
+		ctx, cancel = context.WithCancel(context.Background())
+
+		cluster, err := vmwareService.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+		Expect(err).To(BeNil())
+		site, _, err = vmwareService.CreateWorkloadDomain(vmwareService.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		cancel()
+		server.Close()
+	})
+	next := func(events <-chan vmwarev1.WatchEvent) vmwarev1.WatchEvent {
+		var event vmwarev1.WatchEvent
+		Eventually(events, 5*time.Second).Should(Receive(&event))
+		return event
+	}
+
+	It(`Report existing resources as added, then report changes`, func() {
+		events, err := vmwareService.Watch(ctx, &vmwarev1.WatchOptions{PollInterval: time.Millisecond})
+		Expect(err).To(BeNil())
+
+		event := next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventAdded))
+		Expect(event.Resource).To(Equal(vmwarev1.WatchResourceDirectorSite))
+		Expect(event.ID).To(Equal(*site.ID))
+		Expect(event.OldDirectorSite).To(BeNil())
+		Expect(*event.NewDirectorSite.Status).To(Equal(vmwarev1.DirectorSite_Status_Creating))
+
+		event = next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventAdded))
+		Expect(event.Resource).To(Equal(vmwarev1.WatchResourceCluster))
+		Expect(event.SiteID).To(Equal(*site.ID))
+		clusterID := event.ID
+
+		server.CompletePending()
+		event = next(events)
+		Expect(event.String()).To(Equal("Modified director site " + *site.ID))
+		Expect(*event.OldDirectorSite.Status).To(Equal(vmwarev1.DirectorSite_Status_Creating))
+		Expect(*event.NewDirectorSite.Status).To(Equal(vmwarev1.DirectorSite_Status_Readytouse))
+		event = next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventModified))
+		Expect(event.ID).To(Equal(clusterID))
+		Expect(*event.NewCluster.Status).To(Equal(vmwarev1.DirectorSite_Status_Readytouse))
+
+		directorSite, err := vmwareService.NewNewVDCDirectorSite(*site.ID, &vmwarev1.VDCDirectorSiteCluster{ID: core.StringPtr(clusterID)})
+		Expect(err).To(BeNil())
+		vdc, _, err := vmwareService.CreateVdc(vmwareService.NewCreateVdcOptions("vdc1", directorSite))
+		Expect(err).To(BeNil())
+		event = next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventAdded))
+		Expect(event.Resource).To(Equal(vmwarev1.WatchResourceVDC))
+		Expect(event.ID).To(Equal(*vdc.ID))
+		Expect(event.SiteID).To(Equal(*site.ID))
+
+		server.CompletePending()
+		_, _, err = vmwareService.DeleteVdc(vmwareService.NewDeleteVdcOptions(*vdc.ID))
+		Expect(err).To(BeNil())
+		server.CompletePending()
+		for event = next(events); event.Type != vmwarev1.WatchEventDeleted; event = next(events) {
+			Expect(event.Type).To(Equal(vmwarev1.WatchEventModified))
+		}
+		Expect(event.ID).To(Equal(*vdc.ID))
+		Expect(event.NewVDC).To(BeNil())
+		Expect(event.OldVDC).ToNot(BeNil())
+
+		cancel()
+		Eventually(events).Should(BeClosed())
+	})
+	It(`Report list errors without reporting deletions`, func() {
+		events, err := vmwareService.Watch(ctx, &vmwarev1.WatchOptions{
+			PollInterval: time.Millisecond,
+			SiteID:       *site.ID,
+			Resources:    []string{vmwarev1.WatchResourceDirectorSite},
+		})
+		Expect(err).To(BeNil())
+		event := next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventAdded))
+
+		server.InjectFault("GetSpecificWorkloadDomainInstance", vmwarev1fake.Fault{StatusCode: 503, Times: 1})
+		event = next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventError))
+		Expect(vmwarev1.IsRetryable(event.Err)).To(BeTrue())
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+
+		server.CompletePending()
+		event = next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventModified))
+		Expect(event.Resource).To(Equal(vmwarev1.WatchResourceDirectorSite))
+	})
+	It(`Ignore existing resources once a first snapshot succeeds`, func() {
+		server.InjectFault("ListVdcs", vmwarev1fake.Fault{StatusCode: 500, Times: 1})
+		events, err := vmwareService.Watch(ctx, &vmwarev1.WatchOptions{PollInterval: time.Millisecond, IgnoreExisting: true})
+		Expect(err).To(BeNil())
+
+		event := next(events)
+		Expect(event.Type).To(Equal(vmwarev1.WatchEventError))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+	})
+	It(`Invoke Watch with error: invalid resources`, func() {
+		_, err := vmwareService.Watch(ctx, &vmwarev1.WatchOptions{Resources: []string{"host"}})
+		Expect(err).ToNot(BeNil())
+	})
+})
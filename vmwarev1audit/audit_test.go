@@ -0,0 +1,115 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func record(operation string) vmwarev1.AuditRecord {
+	return vmwarev1.AuditRecord{
+		Time:        time.Date(2022, 11, 15, 10, 30, 0, 0, time.UTC),
+		Operation:   operation,
+		Method:      "PUT",
+		Params:      map[string]string{"site_id": "site1", "cluster_id": "cluster1"},
+		RequestBody: []byte(`{"count":3}`),
+		StatusCode:  202,
+		Identity:    "IBMid-123",
+	}
+}
+
+func TestFileSinkChainsRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := OpenFileSink(path)
+	require.Nil(t, err)
+	require.Nil(t, sink.Audit(context.Background(), record("SetHostsCount")))
+	require.Nil(t, sink.Audit(context.Background(), record("SetFileShares")))
+	require.Nil(t, sink.Close())
+	assert.ErrorIs(t, sink.Audit(context.Background(), record("DeleteVdc")), os.ErrClosed)
+
+	// Reopening appends to the chain.
+	sink, err = OpenFileSink(path)
+	require.Nil(t, err)
+	require.Nil(t, sink.Audit(context.Background(), record("DeleteVdc")))
+	require.Nil(t, sink.Close())
+
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	count, err := Verify(bytes.NewReader(content))
+	require.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	records, err := ReadRecords(bytes.NewReader(content))
+	require.Nil(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, record("SetHostsCount"), records[0])
+	assert.Equal(t, "DeleteVdc", records[2].Operation)
+
+	info, err := os.Stat(path)
+	require.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFileSinkDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := OpenFileSink(path)
+	require.Nil(t, err)
+	for _, operation := range []string{"CreateWorkloadDomain", "SetHostsCount", "DeleteWorkloadDomain"} {
+		require.Nil(t, sink.Audit(context.Background(), record(operation)))
+	}
+	require.Nil(t, sink.Close())
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	lines := bytes.SplitAfter(content, []byte("\n"))
+
+	tampered := bytes.Replace(content, []byte(`"count":3`), []byte(`"count":2`), 1)
+	_, err = Verify(bytes.NewReader(tampered))
+	var chainErr *ChainError
+	require.True(t, errors.As(err, &chainErr), "%v", err)
+	assert.Equal(t, 1, chainErr.Line)
+	assert.Equal(t, "the hash does not match the record", chainErr.Reason)
+
+	removed := append(append([]byte(nil), lines[0]...), lines[2]...)
+	_, err = Verify(bytes.NewReader(removed))
+	require.True(t, errors.As(err, &chainErr))
+	assert.Equal(t, 2, chainErr.Line)
+
+	require.Nil(t, os.WriteFile(path, removed, 0600))
+	_, err = OpenFileSink(path)
+	assert.True(t, errors.As(err, &chainErr))
+}
+
+func TestMemorySink(t *testing.T) {
+	sink := NewMemorySink()
+	require.Nil(t, sink.Audit(context.Background(), record("SetHostsCount")))
+	records := sink.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "SetHostsCount", records[0].Operation)
+
+	sink.Reset()
+	assert.Empty(t, sink.Records())
+}
@@ -0,0 +1,189 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// Entry : A line of an audit file
+// Hash is the hex-encoded SHA-256 of PreviousHash followed by the bytes of Record, so changing, removing or
+// reordering lines breaks the chain. The PreviousHash of the first line is empty.
+type Entry struct {
+	PreviousHash string          `json:"previous_hash"`
+	Hash         string          `json:"hash"`
+	Record       json.RawMessage `json:"record"`
+}
+
+// ChainError is returned by Verify and OpenFileSink when the hash chain of an audit file is broken.
+type ChainError struct {
+	// The line of the file, starting at 1, where the chain breaks.
+	Line int
+
+	// What is wrong with the line.
+	Reason string
+}
+
+// Error returns the error message.
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit file is broken at line %d: %s", e.Line, e.Reason)
+}
+
+// FileSink : An audit sink that appends the records to a hash-chained JSON Lines file
+// Each record is written as an Entry on its own line and synced to disk before Audit returns. The file is only ever
+// opened for appending.
+type FileSink struct {
+	mutex    sync.Mutex
+	file     *os.File
+	lastHash string
+}
+
+var _ vmwarev1.AuditSink = (*FileSink)(nil)
+
+// OpenFileSink : Open an audit file for appending, creating it when it does not exist
+// The chain of an existing file is verified first, and a *ChainError is returned when it is broken. The sink must be
+// closed with Close when it is no longer needed.
+func OpenFileSink(path string) (*FileSink, error) {
+	sink := &FileSink{}
+	existing, err := os.Open(path)
+	if err == nil {
+		_, sink.lastHash, err = verify(existing)
+		existing.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	sink.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// Audit appends the record to the file.
+func (sink *FileSink) Audit(ctx context.Context, record vmwarev1.AuditRecord) error {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return os.ErrClosed
+	}
+	entry := Entry{PreviousHash: sink.lastHash, Hash: chainHash(sink.lastHash, encoded), Record: encoded}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err = sink.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err = sink.file.Sync(); err != nil {
+		return err
+	}
+	sink.lastHash = entry.Hash
+	return nil
+}
+
+// Close closes the file.
+func (sink *FileSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return nil
+	}
+	err := sink.file.Close()
+	sink.file = nil
+	return err
+}
+
+// Verify : Check the hash chain of an audit file
+// Return the number of records in the file, or a *ChainError for the first line that breaks the chain.
+func Verify(r io.Reader) (count int, err error) {
+	count, _, err = verify(r)
+	return
+}
+
+// ReadRecords : Read the records of an audit file, verifying its hash chain
+func ReadRecords(r io.Reader) (records []vmwarev1.AuditRecord, err error) {
+	err = scanEntries(r, func(line int, entry Entry) error {
+		var record vmwarev1.AuditRecord
+		if err := json.Unmarshal(entry.Record, &record); err != nil {
+			return &ChainError{Line: line, Reason: err.Error()}
+		}
+		records = append(records, record)
+		return nil
+	})
+	return
+}
+
+func verify(r io.Reader) (count int, lastHash string, err error) {
+	err = scanEntries(r, func(line int, entry Entry) error {
+		count = line
+		lastHash = entry.Hash
+		return nil
+	})
+	return
+}
+
+// scanEntries calls visit with each entry of the audit file, after checking that it continues the chain.
+func scanEntries(r io.Reader, visit func(line int, entry Entry) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	previousHash := ""
+	line := 0
+	for scanner.Scan() {
+		line++
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return &ChainError{Line: line, Reason: err.Error()}
+		}
+		if entry.PreviousHash != previousHash {
+			return &ChainError{Line: line, Reason: "the previous hash does not match the hash of the line before"}
+		}
+		if entry.Hash != chainHash(entry.PreviousHash, entry.Record) {
+			return &ChainError{Line: line, Reason: "the hash does not match the record"}
+		}
+		if err := visit(line, entry); err != nil {
+			return err
+		}
+		previousHash = entry.Hash
+	}
+	return scanner.Err()
+}
+
+// chainHash returns the hash of a record that follows the record with the previous hash.
+func chainHash(previousHash string, record []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(previousHash))
+	hash.Write(record)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,67 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vmwarev1audit : Sinks for the audit trail of vmwarev1.VmwareV1
+//
+// A FileSink appends audit records to a hash-chained JSON Lines file, and a MemorySink keeps them in memory:
+//
+//	sink, err := vmwarev1audit.OpenFileSink("vmware-audit.jsonl")
+//	...
+//	defer sink.Close()
+//	err = vmwareService.EnableAudit(&vmwarev1.AuditOptions{Sink: sink})
+package vmwarev1audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// MemorySink : An audit sink that keeps the records in memory
+type MemorySink struct {
+	mutex   sync.Mutex
+	records []vmwarev1.AuditRecord
+}
+
+var _ vmwarev1.AuditSink = (*MemorySink)(nil)
+
+// NewMemorySink : Construct an empty MemorySink
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// Audit keeps the record.
+func (sink *MemorySink) Audit(ctx context.Context, record vmwarev1.AuditRecord) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.records = append(sink.records, record)
+	return nil
+}
+
+// Records returns a copy of the records kept so far, oldest first.
+func (sink *MemorySink) Records() []vmwarev1.AuditRecord {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return append([]vmwarev1.AuditRecord(nil), sink.records...)
+}
+
+// Reset drops the records kept so far.
+func (sink *MemorySink) Reset() {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.records = nil
+}
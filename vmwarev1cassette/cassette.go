@@ -0,0 +1,103 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vmwarev1cassette : Record and replay the HTTP traffic of vmwarev1.VmwareV1 in tests
+//
+// A Recorder is an http.RoundTripper. In ModeRecord it sends the requests to the service and saves every request and
+// response to a cassette file when it is stopped; in ModeReplay it answers the requests from the cassette without
+// any network access. Secrets are scrubbed before anything is written with vmwarev1.RedactHeaders and
+// vmwarev1.RedactJSON: the Authorization header, cookies, and body fields such as "password" and "access_token".
+//
+//	recorder, err := vmwarev1cassette.New("testdata/create_site.json", &vmwarev1cassette.Options{
+//		Mode: vmwarev1cassette.ModeFromEnvironment(),
+//	})
+//	...
+//	defer recorder.Stop()
+//	recorder.Attach(vmwareService)
+//
+// Requests are matched to the recorded interactions on their method, path template, such as
+// "/director_sites/{site_id}/clusters/{cluster_id}", and JSON body. Identical requests, such as the polls of a waiter,
+// are answered with the recorded responses in order.
+package vmwarev1cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cassette : The interactions recorded in a cassette file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction : A request and the response it received
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request : A recorded request
+type Request struct {
+	// The operationId of the VmwareV1 method that sent the request, or "" when the request is not a VmwareV1
+	// operation.
+	Operation string `json:"operation,omitempty"`
+
+	Method string `json:"method"`
+
+	// The path template of the operation, or the path of the request when it is not a VmwareV1 operation.
+	Template string `json:"template"`
+
+	// The URL of the request.
+	URL string `json:"url"`
+
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response : A recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load : Read a cassette file
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %s", path, err.Error())
+	}
+	return cassette, nil
+}
+
+// Save : Write the cassette to a file, creating its directory if needed
+func (cassette *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,316 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// Mode : Whether a Recorder records or replays
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette. Requests without a matching interaction fail.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the service and writes them to the cassette when the Recorder is stopped,
+	// replacing its previous content.
+	ModeRecord
+)
+
+// EnvMode is the environment variable read by ModeFromEnvironment.
+const EnvMode = "VMWARE_CASSETTE_MODE"
+
+// ModeFromEnvironment returns ModeRecord when the EnvMode environment variable is "record", and ModeReplay otherwise,
+// so the same test can refresh its cassettes against the real service.
+func ModeFromEnvironment() Mode {
+	if strings.EqualFold(os.Getenv(EnvMode), "record") {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// String returns the name of the mode.
+func (mode Mode) String() string {
+	switch mode {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(mode))
+	}
+}
+
+// Options : Options for a Recorder
+type Options struct {
+	// Whether to record or replay. Defaults to ModeReplay.
+	Mode Mode
+
+	// The transport that sends the requests in ModeRecord. Defaults to the transport replaced by Attach, or
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// JSON body fields to scrub in addition to vmwarev1.RedactedFields.
+	ScrubFields []string
+
+	// Called with every recorded interaction, after the default scrubbing and before it is saved, to scrub
+	// anything else.
+	Scrub func(interaction *Interaction)
+}
+
+// Recorder : An http.RoundTripper that records or replays a cassette
+type Recorder struct {
+	path    string
+	options Options
+
+	mutex    sync.Mutex
+	cassette *Cassette
+	played   []bool
+}
+
+// New : Construct a Recorder for the cassette file
+// In ModeReplay the cassette is read immediately and must exist.
+func New(path string, options *Options) (*Recorder, error) {
+	recorder := &Recorder{
+		path:     path,
+		cassette: &Cassette{},
+	}
+	if options != nil {
+		recorder.options = *options
+	}
+
+	if recorder.options.Mode == ModeReplay {
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		recorder.cassette = cassette
+		recorder.played = make([]bool, len(cassette.Interactions))
+	}
+	return recorder, nil
+}
+
+// Mode returns the mode of the recorder.
+func (recorder *Recorder) Mode() Mode {
+	return recorder.options.Mode
+}
+
+// Attach : Send the requests of the service through the recorder
+// Replaying needs no network access, so use an authenticator that does not fetch tokens, such as
+// core.NoAuthAuthenticator or core.BearerTokenAuthenticator, when replaying.
+func (recorder *Recorder) Attach(service *vmwarev1.VmwareV1) {
+	client := service.Service.GetHTTPClient()
+	if client == nil {
+		client = core.DefaultHTTPClient()
+		service.Service.SetHTTPClient(client)
+	}
+	if recorder.options.Transport == nil {
+		recorder.options.Transport = client.Transport
+	}
+	client.Transport = recorder
+}
+
+// RoundTrip records or replays the request, implementing http.RoundTripper.
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	raw, body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := recorder.newRequest(req, body)
+	if recorder.options.Mode == ModeRecord {
+		return recorder.record(req, raw, request)
+	}
+	return recorder.replay(req, request)
+}
+
+// Stop : Finish recording or replaying
+// In ModeRecord the recorded interactions are written to the cassette file.
+func (recorder *Recorder) Stop() error {
+	if recorder.options.Mode != ModeRecord {
+		return nil
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.cassette.Save(recorder.path)
+}
+
+// Unplayed returns the interactions of the cassette that have not been replayed yet.
+func (recorder *Recorder) Unplayed() []Interaction {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	var unplayed []Interaction
+	for i, played := range recorder.played {
+		if !played {
+			unplayed = append(unplayed, recorder.cassette.Interactions[i])
+		}
+	}
+	return unplayed
+}
+
+// newRequest builds the scrubbed record of the request.
+func (recorder *Recorder) newRequest(req *http.Request, body []byte) Request {
+	request := Request{
+		Method:   req.Method,
+		Template: req.URL.EscapedPath(),
+		URL:      req.URL.String(),
+		Headers:  vmwarev1.RedactHeaders(req.Header),
+		Body:     recorder.scrubBody(body),
+	}
+	if operation, _, ok := vmwarev1.MatchOperation(req.Method, req.URL.EscapedPath()); ok {
+		request.Operation = operation.ID
+		request.Template = operation.Path
+	}
+	return request
+}
+
+func (recorder *Recorder) record(req *http.Request, raw []byte, request Request) (*http.Response, error) {
+	transport := recorder.options.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	outgoing := req.Clone(req.Context())
+	if req.Body != nil {
+		outgoing.Body = io.NopCloser(bytes.NewReader(raw))
+	}
+	res, err := transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: request,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Headers:    vmwarev1.RedactHeaders(res.Header),
+			Body:       recorder.scrubBody(body),
+		},
+	}
+	if recorder.options.Scrub != nil {
+		recorder.options.Scrub(&interaction)
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+	return res, nil
+}
+
+func (recorder *Recorder) replay(req *http.Request, request Request) (*http.Response, error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	for i, interaction := range recorder.cassette.Interactions {
+		if recorder.played[i] || !matches(request, interaction.Request) {
+			continue
+		}
+		recorder.played[i] = true
+		recorded := interaction.Response
+		header := recorded.Headers.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("vmwarev1cassette: no unplayed interaction of %s matches %s %s", recorder.path, request.Method, request.Template)
+}
+
+// matches reports whether the request matches the recorded request on method, path template and body.
+func matches(request Request, recorded Request) bool {
+	return request.Method == recorded.Method && request.Template == recorded.Template && equalBodies(request.Body, recorded.Body)
+}
+
+// equalBodies compares JSON bodies by value, so formatting and field order do not matter.
+func equalBodies(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	valueA, errA := decodeJSON([]byte(a))
+	valueB, errB := decodeJSON([]byte(b))
+	return errA == nil && errB == nil && reflect.DeepEqual(valueA, valueB)
+}
+
+// readRequestBody reads the body of the request as sent and, when it is gzip-compressed, decompressed.
+func readRequestBody(req *http.Request) (raw []byte, body []byte, err error) {
+	if req.Body == nil {
+		return
+	}
+	raw, err = io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return
+	}
+	body = raw
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		var reader *gzip.Reader
+		reader, err = gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return
+		}
+		body, err = io.ReadAll(reader)
+	}
+	return
+}
+
+// scrubBody returns the body with the secret JSON fields redacted. Bodies that are not JSON are returned unchanged.
+func (recorder *Recorder) scrubBody(body []byte) string {
+	scrubbed, err := vmwarev1.RedactJSON(body, recorder.options.ScrubFields...)
+	if err != nil {
+		return string(body)
+	}
+	return string(scrubbed)
+}
+
+// decodeJSON decodes a JSON document, keeping numbers exact.
+func decodeJSON(data []byte) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&value); err != nil {
+		return
+	}
+	if decoder.More() {
+		err = fmt.Errorf("trailing data after JSON document")
+	}
+	return
+}
@@ -0,0 +1,158 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1cassette
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newService returns a client of the URL that authenticates with a bearer token.
+func newService(t *testing.T, url string) *vmwarev1.VmwareV1 {
+	service, err := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+		URL:           url,
+		Authenticator: &core.BearerTokenAuthenticator{BearerToken: "secret-token"},
+	})
+	require.Nil(t, err)
+	return service
+}
+
+// exercise calls the operations covered by the test cassette.
+func exercise(t *testing.T, service *vmwarev1.VmwareV1) (site *vmwarev1.DirectorSite, cluster *vmwarev1.Cluster, password *vmwarev1.NewPassword) {
+	order, err := service.NewClusterOrderInfo("cluster1", "dal10", 2, &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)}, vmwarev1fake.HostProfile192GB)
+	require.Nil(t, err)
+	site, _, err = service.CreateWorkloadDomain(service.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*order}))
+	require.Nil(t, err)
+	clusterID := *site.Clusters[0].ID
+	_, _, err = service.SetHostsCount(service.NewSetHostsCountOptions(*site.ID, clusterID, 3))
+	require.Nil(t, err)
+	cluster, _, err = service.GetSpecificClusterInstance(service.NewGetSpecificClusterInstanceOptions(*site.ID, clusterID))
+	require.Nil(t, err)
+	password, _, err = service.ReplaceOrgAdminPassword(service.NewReplaceOrgAdminPasswordOptions(*site.ID))
+	require.Nil(t, err)
+	return
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "site.json")
+
+	server := vmwarev1fake.NewServer(nil)
+	recorder, err := New(path, &Options{Mode: ModeRecord})
+	require.Nil(t, err)
+	service := newService(t, server.URL)
+	recorder.Attach(service)
+	site, cluster, password := exercise(t, service)
+	require.Nil(t, recorder.Stop())
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	assert.NotContains(t, string(data), *password.Password)
+
+	cassette, err := Load(path)
+	require.Nil(t, err)
+	require.Len(t, cassette.Interactions, 4)
+	getCluster := cassette.Interactions[2].Request
+	assert.Equal(t, "GetSpecificClusterInstance", getCluster.Operation)
+	assert.Equal(t, "GET", getCluster.Method)
+	assert.Equal(t, "/director_sites/{site_id}/clusters/{cluster_id}", getCluster.Template)
+	assert.Equal(t, []string{vmwarev1.Redacted}, getCluster.Headers["Authorization"])
+	assert.Equal(t, `{"count":3}`, cassette.Interactions[1].Request.Body)
+	assert.Equal(t, `{"password":"[REDACTED]"}`, cassette.Interactions[3].Response.Body)
+
+	// The fake server is closed, so everything comes from the cassette.
+	replayer, err := New(path, nil)
+	require.Nil(t, err)
+	assert.Equal(t, ModeReplay, replayer.Mode())
+	service = newService(t, server.URL)
+	replayer.Attach(service)
+	replayedSite, replayedCluster, replayedPassword := exercise(t, service)
+	assert.Equal(t, site.ID, replayedSite.ID)
+	assert.Equal(t, cluster.HostCount, replayedCluster.HostCount)
+	assert.Equal(t, vmwarev1.Redacted, *replayedPassword.Password)
+	assert.Empty(t, replayer.Unplayed())
+
+	_, _, err = service.GetRegions(service.NewGetRegionsOptions())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no unplayed interaction")
+}
+
+func TestReplayMatchesBodies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.json")
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+	cassette := &Cassette{Interactions: []Interaction{
+		{
+			Request: Request{
+				Method:   "PUT",
+				Template: "/director_sites/{site_id}/clusters/{cluster_id}/hosts_count",
+				Body:     `{"count":3}`,
+			},
+			Response: Response{StatusCode: 202, Headers: jsonHeader, Body: `{"message":"three"}`},
+		},
+		{
+			Request: Request{
+				Method:   "PUT",
+				Template: "/director_sites/{site_id}/clusters/{cluster_id}/hosts_count",
+				Body:     `{"count": 4}`,
+			},
+			Response: Response{StatusCode: 202, Headers: jsonHeader, Body: `{"message":"four"}`},
+		},
+	}}
+	require.Nil(t, cassette.Save(path))
+
+	recorder, err := New(path, nil)
+	require.Nil(t, err)
+	service := newService(t, "https://replay.invalid/v1")
+	recorder.Attach(service)
+
+	// Path parameters are not matched, only the template.
+	result, _, err := service.SetHostsCount(service.NewSetHostsCountOptions("anySite", "anyCluster", 4))
+	require.Nil(t, err)
+	assert.Equal(t, "four", *result.Message)
+	_, _, err = service.SetHostsCount(service.NewSetHostsCountOptions("anySite", "anyCluster", 5))
+	require.NotNil(t, err)
+	assert.Len(t, recorder.Unplayed(), 1)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), &Options{Mode: ModeReplay})
+	assert.NotNil(t, err)
+
+	t.Setenv(EnvMode, "record")
+	assert.Equal(t, ModeRecord, ModeFromEnvironment())
+	t.Setenv(EnvMode, "")
+	assert.Equal(t, ModeReplay, ModeFromEnvironment())
+	assert.Equal(t, "record", ModeRecord.String())
+}
+
+func TestScrub(t *testing.T) {
+	recorder, err := New("", &Options{Mode: ModeRecord, ScrubFields: []string{"secret"}})
+	require.Nil(t, err)
+	scrubbed := recorder.scrubBody([]byte(`{"nested":[{"secret":"a","access_token":"b","count":12345678901234567890}]}`))
+	assert.Equal(t, `{"nested":[{"access_token":"[REDACTED]","count":12345678901234567890,"secret":"[REDACTED]"}]}`, scrubbed)
+	assert.Equal(t, "not json", recorder.scrubBody([]byte("not json")))
+	assert.Equal(t, []string{vmwarev1.Redacted}, vmwarev1.RedactHeaders(http.Header{"authorization": {"Bearer x"}})["authorization"])
+}
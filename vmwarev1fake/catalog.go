@@ -0,0 +1,117 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1fake
+
+import (
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// Reference data served by the fake when ServerOptions does not provide its own.
+const (
+	DefaultCountry  = "USA"
+	DefaultCurrency = "USD"
+
+	// The host profiles of DefaultHostProfiles.
+	HostProfile192GB = "BM_2S_20_CORES_192_GB"
+	HostProfile384GB = "BM_2S_28_CORES_384_GB"
+	HostProfile768GB = "BM_2S_32_CORES_768_GB"
+)
+
+// DefaultRegions : The regions served by default
+func DefaultRegions() map[string]vmwarev1.RegionDetail {
+	datacenter := func(name string, displayName string) vmwarev1.DataCenterInfo {
+		return vmwarev1.DataCenterInfo{
+			Name:        core.StringPtr(name),
+			DisplayName: core.StringPtr(displayName),
+			UplinkSpeed: core.StringPtr("10 Gbps"),
+		}
+	}
+	return map[string]vmwarev1.RegionDetail{
+		"us-south": {
+			Datacenters: []vmwarev1.DataCenterInfo{
+				datacenter("dal10", "Dallas 10"),
+				datacenter("dal12", "Dallas 12"),
+				datacenter("dal13", "Dallas 13"),
+			},
+			Endpoint: core.StringPtr("https://api.us-south.vmware.cloud.ibm.com"),
+		},
+		"eu-de": {
+			Datacenters: []vmwarev1.DataCenterInfo{
+				datacenter("fra02", "Frankfurt 2"),
+				datacenter("fra04", "Frankfurt 4"),
+				datacenter("fra05", "Frankfurt 5"),
+			},
+			Endpoint: core.StringPtr("https://api.eu-de.vmware.cloud.ibm.com"),
+		},
+	}
+}
+
+// DefaultHostProfiles : The host profiles served by default
+func DefaultHostProfiles() []vmwarev1.HostProfile {
+	profile := func(name string, cpuCount int64, ram int64) vmwarev1.HostProfile {
+		return vmwarev1.HostProfile{
+			ProfileName: core.StringPtr(name),
+			CpuType:     core.StringPtr("Intel Xeon"),
+			CpuCount:    core.Int64Ptr(cpuCount),
+			Ram:         core.Int64Ptr(ram),
+			LocalDisks: []vmwarev1.HostProfileDisk{
+				{
+					Quantity: core.Int64Ptr(2),
+					Size:     core.Int64Ptr(960),
+					Type:     core.StringPtr("SSD"),
+				},
+			},
+		}
+	}
+	return []vmwarev1.HostProfile{
+		profile(HostProfile192GB, 40, 192),
+		profile(HostProfile384GB, 56, 384),
+		profile(HostProfile768GB, 64, 768),
+	}
+}
+
+// DefaultPricing : The price catalog served by default
+// Host profiles are charged per host and storage tiers per GB. Host prices drop from the tenth host of a cluster on.
+func DefaultPricing() []vmwarev1.DirectorSitePriceMetric {
+	metric := func(name string, description string, prices ...vmwarev1.DirectorSitePriceItem) vmwarev1.DirectorSitePriceMetric {
+		return vmwarev1.DirectorSitePriceMetric{
+			Metric:      core.StringPtr(name),
+			Description: core.StringPtr(description),
+			PriceList: []vmwarev1.DirectorSitePriceListItem{
+				{
+					Country:  core.StringPtr(DefaultCountry),
+					Currency: core.StringPtr(DefaultCurrency),
+					Prices:   prices,
+				},
+			},
+		}
+	}
+	tier := func(price float64, quantityTier int64) vmwarev1.DirectorSitePriceItem {
+		return vmwarev1.DirectorSitePriceItem{Price: core.Float64Ptr(price), QuantityTier: core.Int64Ptr(quantityTier)}
+	}
+	return []vmwarev1.DirectorSitePriceMetric{
+		metric(vmwarev1.BaseChargeMetric, "Director site base charge", tier(1500, 1)),
+		metric(HostProfile192GB, "Host with 2 sockets, 20 cores and 192 GB RAM", tier(3000, 1), tier(2700, 10)),
+		metric(HostProfile384GB, "Host with 2 sockets, 28 cores and 384 GB RAM", tier(4200, 1), tier(3800, 10)),
+		metric(HostProfile768GB, "Host with 2 sockets, 32 cores and 768 GB RAM", tier(6000, 1), tier(5400, 10)),
+		metric(vmwarev1.StorageTierPointTwoFiveIopsGB, "0.25 IOPS/GB file storage per GB", tier(0.06, 1)),
+		metric(vmwarev1.StorageTierTwoIopsGB, "2 IOPS/GB file storage per GB", tier(0.12, 1)),
+		metric(vmwarev1.StorageTierFourIopsGB, "4 IOPS/GB file storage per GB", tier(0.2, 1)),
+		metric(vmwarev1.StorageTierTenIopsGB, "10 IOPS/GB file storage per GB", tier(0.38, 1)),
+	}
+}
@@ -0,0 +1,486 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/go-openapi/strfmt"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// site is a stored director site with its clusters.
+type site struct {
+	model    vmwarev1.DirectorSite
+	clusters []*vmwarev1.Cluster
+}
+
+// vdc is a stored Virtual Data Center.
+type vdc struct {
+	model vmwarev1.VDC
+}
+
+// pendingTransition completes an asynchronous operation once its time has come.
+type pendingTransition struct {
+	at    time.Time
+	apply func()
+}
+
+// schedule registers apply to run once the transition delay has elapsed.
+func (server *Server) schedule(apply func()) {
+	server.pending = append(server.pending, pendingTransition{
+		at:    server.options.Now().Add(server.options.TransitionDelay),
+		apply: apply,
+	})
+}
+
+// advance applies the pending transitions that are due, or all of them when force is set.
+func (server *Server) advance(force bool) {
+	now := server.options.Now()
+	remaining := server.pending[:0]
+	var due []pendingTransition
+	for _, transition := range server.pending {
+		if force || !transition.at.After(now) {
+			due = append(due, transition)
+		} else {
+			remaining = append(remaining, transition)
+		}
+	}
+	server.pending = remaining
+	for _, transition := range due {
+		transition.apply()
+	}
+}
+
+func (server *Server) timestamp() string {
+	return server.options.Now().UTC().Format(time.RFC3339)
+}
+
+func (server *Server) dateTime() *strfmt.DateTime {
+	dateTime := strfmt.DateTime(server.options.Now().UTC())
+	return &dateTime
+}
+
+// render returns the director site as returned by the service, with a summary of its clusters.
+func (s *site) render() *vmwarev1.DirectorSite {
+	model := s.model
+	model.Clusters = make([]vmwarev1.ClusterSummary, 0, len(s.clusters))
+	for _, cluster := range s.clusters {
+		model.Clusters = append(model.Clusters, vmwarev1.ClusterSummary{
+			ID:          cluster.ID,
+			Name:        cluster.Name,
+			Location:    cluster.Location,
+			HostCount:   cluster.HostCount,
+			Status:      cluster.Status,
+			ClusterName: cluster.Name,
+			HostProfile: cluster.HostProfile,
+			FileShares:  cluster.FileShares,
+		})
+	}
+	return &model
+}
+
+// refreshStatus sets the status of an existing director site from the status of its clusters.
+func (s *site) refreshStatus() {
+	status := vmwarev1.DirectorSite_Status_Readytouse
+	for _, cluster := range s.clusters {
+		if core.StringNilMapper(cluster.Status) != vmwarev1.DirectorSite_Status_Readytouse {
+			status = vmwarev1.DirectorSite_Status_Updating
+		}
+	}
+	s.model.Status = core.StringPtr(status)
+}
+
+func (s *site) cluster(clusterID string) *vmwarev1.Cluster {
+	for _, cluster := range s.clusters {
+		if *cluster.ID == clusterID {
+			return cluster
+		}
+	}
+	return nil
+}
+
+// fileSharesMap converts ordered file shares to the untyped form returned on clusters.
+func fileSharesMap(fileShares *vmwarev1.FileShares) map[string]interface{} {
+	m := make(map[string]interface{})
+	if fileShares == nil {
+		return m
+	}
+	buf, _ := json.Marshal(fileShares)
+	_ = json.Unmarshal(buf, &m)
+	return m
+}
+
+func (server *Server) lookupSite(siteID string) (*site, int, interface{}) {
+	s, ok := server.sites[siteID]
+	if !ok {
+		status, body := failure(http.StatusNotFound, "director_site_not_found", "director site %s not found", siteID)
+		return nil, status, body
+	}
+	return s, 0, nil
+}
+
+func (server *Server) lookupCluster(siteID string, clusterID string) (*site, *vmwarev1.Cluster, int, interface{}) {
+	s, status, body := server.lookupSite(siteID)
+	if s == nil {
+		return nil, nil, status, body
+	}
+	cluster := s.cluster(clusterID)
+	if cluster == nil {
+		status, body = failure(http.StatusNotFound, "cluster_not_found", "cluster %s not found in director site %s", clusterID, siteID)
+		return nil, nil, status, body
+	}
+	return s, cluster, 0, nil
+}
+
+func (server *Server) createWorkloadDomain(req *http.Request, params map[string]string) (int, interface{}) {
+	var body struct {
+		Name          *string                     `json:"name"`
+		ResourceGroup *string                     `json:"resource_group"`
+		Clusters      []vmwarev1.ClusterOrderInfo `json:"clusters"`
+	}
+	if err := decodeBody(req, &body); err != nil {
+		return failure(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	if body.Name == nil || body.ResourceGroup == nil || len(body.Clusters) == 0 {
+		return failure(http.StatusBadRequest, "bad_request", "name, resource_group and clusters are required")
+	}
+	for _, order := range body.Clusters {
+		if err := core.ValidateStruct(&order, "cluster"); err != nil {
+			return failure(http.StatusBadRequest, "bad_request", err.Error())
+		}
+	}
+
+	siteID := server.newID("site")
+	s := &site{
+		model: vmwarev1.DirectorSite{
+			ID:               core.StringPtr(siteID),
+			InstanceOrdered:  core.StringPtr(server.timestamp()),
+			Name:             body.Name,
+			Status:           core.StringPtr(vmwarev1.DirectorSite_Status_Creating),
+			ResourceGroup:    body.ResourceGroup,
+			Requester:        core.StringPtr("fake@example.com"),
+			ResourceGroupID:  body.ResourceGroup,
+			ResourceGroupCrn: core.StringPtr("crn:v1:bluemix:public:resource-controller::a/fake::resource-group:" + *body.ResourceGroup),
+		},
+	}
+	for _, order := range body.Clusters {
+		s.clusters = append(s.clusters, &vmwarev1.Cluster{
+			ID:              core.StringPtr(server.newID("cluster")),
+			Name:            order.Name,
+			InstanceOrdered: s.model.InstanceOrdered,
+			Location:        order.Location,
+			HostCount:       order.HostCount,
+			Status:          core.StringPtr(vmwarev1.DirectorSite_Status_Creating),
+			SiteID:          core.StringPtr(siteID),
+			HostProfile:     order.HostProfile,
+			StorageType:     core.StringPtr(vmwarev1.Cluster_StorageType_Nfs),
+			BillingPlan:     core.StringPtr(vmwarev1.Cluster_BillingPlan_Monthly),
+			FileShares:      fileSharesMap(order.FileShares),
+		})
+	}
+	server.sites[siteID] = s
+	server.siteOrder = append(server.siteOrder, siteID)
+
+	server.schedule(func() {
+		created := core.StringPtr(server.timestamp())
+		s.model.InstanceCreated = created
+		for _, cluster := range s.clusters {
+			cluster.InstanceCreated = created
+			cluster.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Readytouse)
+		}
+		s.refreshStatus()
+	})
+	return http.StatusAccepted, s.render()
+}
+
+func (server *Server) listWorkloadDomainInstances(req *http.Request, params map[string]string) (int, interface{}) {
+	result := &vmwarev1.ListDirectorSites{DirectorSites: []vmwarev1.DirectorSite{}}
+	for _, siteID := range server.siteOrder {
+		result.DirectorSites = append(result.DirectorSites, *server.sites[siteID].render())
+	}
+	return http.StatusOK, result
+}
+
+func (server *Server) getSpecificWorkloadDomainInstance(req *http.Request, params map[string]string) (int, interface{}) {
+	s, status, body := server.lookupSite(params["site_id"])
+	if s == nil {
+		return status, body
+	}
+	return http.StatusOK, s.render()
+}
+
+func (server *Server) deleteWorkloadDomain(req *http.Request, params map[string]string) (int, interface{}) {
+	s, status, body := server.lookupSite(params["site_id"])
+	if s == nil {
+		return status, body
+	}
+	switch core.StringNilMapper(s.model.Status) {
+	case vmwarev1.DirectorSite_Status_Deleting, vmwarev1.DirectorSite_Status_Deleted:
+		return failure(http.StatusConflict, "director_site_deleting", "director site %s is already %s", *s.model.ID, *s.model.Status)
+	}
+	for _, v := range server.vdcs {
+		if *v.model.DirectorSite.ID == *s.model.ID {
+			return failure(http.StatusConflict, "director_site_has_vdcs", "director site %s still has Virtual Data Centers", *s.model.ID)
+		}
+	}
+
+	s.model.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Deleting)
+	for _, cluster := range s.clusters {
+		cluster.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Deleting)
+	}
+	server.schedule(func() {
+		deleted := core.StringPtr(server.timestamp())
+		s.model.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Deleted)
+		for _, cluster := range s.clusters {
+			cluster.InstanceDeleted = deleted
+			cluster.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Deleted)
+		}
+	})
+	return http.StatusAccepted, s.render()
+}
+
+func (server *Server) listClusterInstances(req *http.Request, params map[string]string) (int, interface{}) {
+	s, status, body := server.lookupSite(params["site_id"])
+	if s == nil {
+		return status, body
+	}
+	result := &vmwarev1.ListClusters{Clusters: []vmwarev1.Cluster{}}
+	for _, cluster := range s.clusters {
+		result.Clusters = append(result.Clusters, *cluster)
+	}
+	return http.StatusOK, result
+}
+
+func (server *Server) getSpecificClusterInstance(req *http.Request, params map[string]string) (int, interface{}) {
+	_, cluster, status, body := server.lookupCluster(params["site_id"], params["cluster_id"])
+	if cluster == nil {
+		return status, body
+	}
+	return http.StatusOK, cluster
+}
+
+// startClusterUpdate marks the cluster and its director site as updating and schedules apply. It fails when the
+// director site is not ReadyToUse.
+func (server *Server) startClusterUpdate(s *site, cluster *vmwarev1.Cluster, apply func()) (int, interface{}) {
+	if core.StringNilMapper(s.model.Status) != vmwarev1.DirectorSite_Status_Readytouse {
+		return failure(http.StatusConflict, "director_site_not_ready", "director site %s is %s", *s.model.ID, core.StringNilMapper(s.model.Status))
+	}
+	cluster.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Updating)
+	s.refreshStatus()
+	server.schedule(func() {
+		apply()
+		cluster.Status = core.StringPtr(vmwarev1.DirectorSite_Status_Readytouse)
+		s.refreshStatus()
+	})
+	return 0, nil
+}
+
+func (server *Server) setHostsCount(req *http.Request, params map[string]string) (int, interface{}) {
+	s, cluster, status, body := server.lookupCluster(params["site_id"], params["cluster_id"])
+	if cluster == nil {
+		return status, body
+	}
+	var request struct {
+		Count *int64 `json:"count"`
+	}
+	if err := decodeBody(req, &request); err != nil {
+		return failure(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	if request.Count == nil || *request.Count < vmwarev1.MinimumClusterHostCount {
+		return failure(http.StatusBadRequest, "bad_request", fmt.Sprintf("count must be at least %d", vmwarev1.MinimumClusterHostCount))
+	}
+	count := *request.Count
+	if status, body := server.startClusterUpdate(s, cluster, func() {
+		cluster.HostCount = core.Int64Ptr(count)
+	}); status != 0 {
+		return status, body
+	}
+	return http.StatusAccepted, &vmwarev1.SetHostsCountResponse{
+		Message: core.StringPtr("The request has been accepted."),
+	}
+}
+
+func (server *Server) setFileShares(req *http.Request, params map[string]string) (int, interface{}) {
+	s, cluster, status, body := server.lookupCluster(params["site_id"], params["cluster_id"])
+	if cluster == nil {
+		return status, body
+	}
+	fileShares := new(vmwarev1.FileShares)
+	if err := decodeBody(req, fileShares); err != nil {
+		return failure(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	if status, body := server.startClusterUpdate(s, cluster, func() {
+		cluster.FileShares = fileSharesMap(fileShares)
+	}); status != 0 {
+		return status, body
+	}
+	return http.StatusAccepted, fileShares
+}
+
+func (server *Server) getRegions(req *http.Request, params map[string]string) (int, interface{}) {
+	return http.StatusOK, &vmwarev1.DirectorSiteRegions{DirectorSiteRegions: server.options.Regions}
+}
+
+func (server *Server) viewInstance(req *http.Request, params map[string]string) (int, interface{}) {
+	return http.StatusOK, &vmwarev1.ListHostProfiles{DirectorSiteHostProfiles: server.options.HostProfiles}
+}
+
+func (server *Server) replaceOrgAdminPassword(req *http.Request, params map[string]string) (int, interface{}) {
+	s, status, body := server.lookupSite(req.URL.Query().Get("site_id"))
+	if s == nil {
+		return status, body
+	}
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return failure(http.StatusInternalServerError, "internal_error", err.Error())
+	}
+	return http.StatusOK, &vmwarev1.NewPassword{Password: core.StringPtr(hex.EncodeToString(buf))}
+}
+
+func (server *Server) listPrices(req *http.Request, params map[string]string) (int, interface{}) {
+	return http.StatusOK, &vmwarev1.DirectorSitePricingInfo{DirectorSitePricing: server.options.Pricing}
+}
+
+func (server *Server) getVcddPrice(req *http.Request, params map[string]string) (int, interface{}) {
+	var request struct {
+		Country  *string                                      `json:"country"`
+		Clusters []vmwarev1.DirectorSitePriceQuoteClusterInfo `json:"clusters"`
+	}
+	if err := decodeBody(req, &request); err != nil {
+		return failure(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	country := DefaultCountry
+	if request.Country != nil {
+		country = *request.Country
+	}
+	estimator, err := vmwarev1.NewPriceEstimator(&vmwarev1.DirectorSitePricingInfo{DirectorSitePricing: server.options.Pricing})
+	if err != nil {
+		return failure(http.StatusInternalServerError, "internal_error", err.Error())
+	}
+	quote, err := estimator.Estimate(country, request.Clusters)
+	if err != nil {
+		return failure(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	return http.StatusCreated, quote
+}
+
+func (server *Server) listVdcs(req *http.Request, params map[string]string) (int, interface{}) {
+	result := &vmwarev1.ListVDCs{Vdcs: []vmwarev1.VDC{}}
+	for _, vdcID := range server.vdcOrder {
+		result.Vdcs = append(result.Vdcs, server.vdcs[vdcID].model)
+	}
+	return http.StatusOK, result
+}
+
+func (server *Server) createVdc(req *http.Request, params map[string]string) (int, interface{}) {
+	var request struct {
+		Name          *string                       `json:"name"`
+		DirectorSite  *vmwarev1.NewVDCDirectorSite  `json:"director_site"`
+		Edge          *vmwarev1.NewVDCEdge          `json:"edge"`
+		ResourceGroup *vmwarev1.NewVDCResourceGroup `json:"resource_group"`
+	}
+	if err := decodeBody(req, &request); err != nil {
+		return failure(http.StatusBadRequest, "bad_request", err.Error())
+	}
+	if request.Name == nil || request.DirectorSite == nil || request.DirectorSite.ID == nil ||
+		request.DirectorSite.Cluster == nil || request.DirectorSite.Cluster.ID == nil {
+		return failure(http.StatusBadRequest, "bad_request", "name, director_site.id and director_site.cluster.id are required")
+	}
+	s, _, status, body := server.lookupCluster(*request.DirectorSite.ID, *request.DirectorSite.Cluster.ID)
+	if s == nil {
+		return status, body
+	}
+	if core.StringNilMapper(s.model.Status) != vmwarev1.DirectorSite_Status_Readytouse {
+		return failure(http.StatusConflict, "director_site_not_ready", "director site %s is %s", *s.model.ID, core.StringNilMapper(s.model.Status))
+	}
+
+	edge := vmwarev1.Edge{
+		ID:        core.StringPtr(server.newID("edge")),
+		PublicIps: []string{},
+		Type:      core.StringPtr(vmwarev1.Edge_Type_Shared),
+	}
+	if request.Edge != nil {
+		edge.Type = request.Edge.Type
+		edge.Size = request.Edge.Size
+	}
+	vdcID := server.newID("vdc")
+	v := &vdc{
+		model: vmwarev1.VDC{
+			ID:              core.StringPtr(vdcID),
+			AllocationModel: core.StringPtr(vmwarev1.VDC_AllocationModel_Paygo),
+			Crn:             core.StringPtr("crn:v1:bluemix:public:vmware:us-south:a/fake::vdc:" + vdcID),
+			DirectorSite: &vmwarev1.VDCDirectorSite{
+				ID:      request.DirectorSite.ID,
+				Cluster: request.DirectorSite.Cluster,
+				URL:     core.StringPtr("https://director.fake.vmware.cloud.ibm.com/tenant/" + vdcID),
+			},
+			Edges:       []vmwarev1.Edge{edge},
+			Errors:      []vmwarev1.Error{},
+			Name:        request.Name,
+			OrderedTime: server.dateTime(),
+			OrgName:     core.StringPtr("org-" + vdcID),
+			Status:      core.StringPtr(vmwarev1.VDC_Status_Creating),
+			Type:        core.StringPtr(vmwarev1.VDC_Type_Dedicated),
+		},
+	}
+	server.vdcs[vdcID] = v
+	server.vdcOrder = append(server.vdcOrder, vdcID)
+
+	server.schedule(func() {
+		v.model.CreatedTime = server.dateTime()
+		v.model.Edges[0].PublicIps = []string{fmt.Sprintf("192.0.2.%d", len(server.vdcOrder))}
+		v.model.Status = core.StringPtr(vmwarev1.VDC_Status_Readytouse)
+	})
+	return http.StatusAccepted, &v.model
+}
+
+func (server *Server) getVdc(req *http.Request, params map[string]string) (int, interface{}) {
+	v, ok := server.vdcs[params["vdc_id"]]
+	if !ok {
+		return failure(http.StatusNotFound, "vdc_not_found", "Virtual Data Center %s not found", params["vdc_id"])
+	}
+	return http.StatusOK, &v.model
+}
+
+func (server *Server) deleteVdc(req *http.Request, params map[string]string) (int, interface{}) {
+	v, ok := server.vdcs[params["vdc_id"]]
+	if !ok {
+		return failure(http.StatusNotFound, "vdc_not_found", "Virtual Data Center %s not found", params["vdc_id"])
+	}
+	if core.StringNilMapper(v.model.Status) == vmwarev1.VDC_Status_Deleting {
+		return failure(http.StatusConflict, "vdc_deleting", "Virtual Data Center %s is already being deleted", *v.model.ID)
+	}
+	v.model.Status = core.StringPtr(vmwarev1.VDC_Status_Deleting)
+	server.schedule(func() {
+		vdcID := *v.model.ID
+		delete(server.vdcs, vdcID)
+		for i, id := range server.vdcOrder {
+			if id == vdcID {
+				server.vdcOrder = append(server.vdcOrder[:i], server.vdcOrder[i+1:]...)
+				break
+			}
+		}
+	})
+	return http.StatusAccepted, &v.model
+}
@@ -0,0 +1,293 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vmwarev1fake : An in-memory fake of the VMware as a Service API for offline testing
+//
+// The fake runs an httptest.Server that implements every route used by vmwarev1.VmwareV1. Director sites, clusters
+// and Virtual Data Centers are kept in memory, asynchronous operations move through the same statuses as the real
+// service (Creating -> ReadyToUse, Updating -> ReadyToUse, Deleting -> Deleted), and faults can be injected per
+// operation to exercise error handling.
+package vmwarev1fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+)
+
+// ServerOptions : Options for the fake server
+type ServerOptions struct {
+	// How long asynchronous operations stay in their transient status (Creating, Updating, Modifying, Deleting)
+	// before they complete. Zero completes them on the next request after the operation was accepted.
+	TransitionDelay time.Duration
+
+	// The clock used for transitions. Defaults to time.Now.
+	Now func() time.Time
+
+	// The regions returned by GetRegions. Defaults to DefaultRegions().
+	Regions map[string]vmwarev1.RegionDetail
+
+	// The host profiles returned by ViewInstance. Defaults to DefaultHostProfiles().
+	HostProfiles []vmwarev1.HostProfile
+
+	// The price catalog returned by ListPrices and used to answer GetVcddPrice. Defaults to DefaultPricing().
+	Pricing []vmwarev1.DirectorSitePriceMetric
+
+	// Consulted before every request. Returning a non-nil fault fails the request with that fault.
+	FaultHook func(operation string, req *http.Request) *Fault
+}
+
+// Fault : An error injected into the responses of the fake server
+type Fault struct {
+	// The HTTP status code of the error response.
+	StatusCode int
+
+	// The errors returned in the response body. Defaults to a single error derived from StatusCode.
+	Errors []vmwarev1.Error
+
+	// The number of requests that fail with this fault. Zero fails every request until the fault is cleared.
+	Times int
+}
+
+// Server : A running fake of the VMware as a Service API
+type Server struct {
+	// The underlying test server. Its URL is the service URL to use with vmwarev1.VmwareV1.
+	*httptest.Server
+
+	options ServerOptions
+	mutex   sync.Mutex
+	nextID  int
+	faults  map[string][]*Fault
+	sites   map[string]*site
+	vdcs    map[string]*vdc
+	// The IDs of sites and VDCs in creation order, so list operations are stable.
+	siteOrder []string
+	vdcOrder  []string
+	pending   []pendingTransition
+	requests  map[string]int
+}
+
+// NewServer : Start a fake server
+// The server must be closed with Close when it is no longer needed.
+func NewServer(options *ServerOptions) *Server {
+	server := &Server{
+		faults:   make(map[string][]*Fault),
+		sites:    make(map[string]*site),
+		vdcs:     make(map[string]*vdc),
+		requests: make(map[string]int),
+	}
+	if options != nil {
+		server.options = *options
+	}
+	if server.options.Now == nil {
+		server.options.Now = time.Now
+	}
+	if server.options.Regions == nil {
+		server.options.Regions = DefaultRegions()
+	}
+	if server.options.HostProfiles == nil {
+		server.options.HostProfiles = DefaultHostProfiles()
+	}
+	if server.options.Pricing == nil {
+		server.options.Pricing = DefaultPricing()
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	return server
+}
+
+// NewClient : Construct a VmwareV1 client that sends its requests to the fake server
+func (server *Server) NewClient() (*vmwarev1.VmwareV1, error) {
+	return vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+}
+
+// InjectFault : Fail requests for the operation with the fault
+// The operation is the operationId of the VmwareV1 method, such as "CreateWorkloadDomain". Faults injected for the same
+// operation are used in order.
+func (server *Server) InjectFault(operation string, fault Fault) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults[operation] = append(server.faults[operation], &fault)
+}
+
+// ClearFaults : Remove every injected fault
+func (server *Server) ClearFaults() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = make(map[string][]*Fault)
+}
+
+// CompletePending : Complete every asynchronous operation immediately
+func (server *Server) CompletePending() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.advance(true)
+}
+
+// RequestCount : The number of requests received for the operation, including failed ones
+func (server *Server) RequestCount(operation string) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.requests[operation]
+}
+
+// route : A route of the service API
+type route struct {
+	method    string
+	template  string
+	operation string
+	handler   func(server *Server, req *http.Request, params map[string]string) (int, interface{})
+}
+
+var routes = []route{
+	{"POST", "/director_sites", "CreateWorkloadDomain", (*Server).createWorkloadDomain},
+	{"GET", "/director_sites", "ListWorkloadDomainInstances", (*Server).listWorkloadDomainInstances},
+	{"GET", "/director_sites/{site_id}", "GetSpecificWorkloadDomainInstance", (*Server).getSpecificWorkloadDomainInstance},
+	{"DELETE", "/director_sites/{site_id}", "DeleteWorkloadDomain", (*Server).deleteWorkloadDomain},
+	{"GET", "/director_sites/{site_id}/clusters", "ListClusterInstances", (*Server).listClusterInstances},
+	{"GET", "/director_sites/{site_id}/clusters/{cluster_id}", "GetSpecificClusterInstance", (*Server).getSpecificClusterInstance},
+	{"PUT", "/director_sites/{site_id}/clusters/{cluster_id}/hosts_count", "SetHostsCount", (*Server).setHostsCount},
+	{"PUT", "/director_sites/{site_id}/clusters/{cluster_id}/file_shares", "SetFileShares", (*Server).setFileShares},
+	{"GET", "/director_site_regions", "GetRegions", (*Server).getRegions},
+	{"GET", "/director_site_host_profiles", "ViewInstance", (*Server).viewInstance},
+	{"PUT", "/director_site_password", "ReplaceOrgAdminPassword", (*Server).replaceOrgAdminPassword},
+	{"GET", "/director_site_pricing", "ListPrices", (*Server).listPrices},
+	{"POST", "/director_site_price_quote", "GetVcddPrice", (*Server).getVcddPrice},
+	{"GET", "/vdcs", "ListVdcs", (*Server).listVdcs},
+	{"POST", "/vdcs", "CreateVdc", (*Server).createVdc},
+	{"GET", "/vdcs/{vdc_id}", "GetVdc", (*Server).getVdc},
+	{"DELETE", "/vdcs/{vdc_id}", "DeleteVdc", (*Server).deleteVdc},
+}
+
+// matchRoute returns the route for the request and the values of its path parameters.
+func matchRoute(method string, path string) (*route, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range routes {
+		if routes[i].method != method {
+			continue
+		}
+		templateSegments := strings.Split(strings.Trim(routes[i].template, "/"), "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+		params := make(map[string]string)
+		matched := true
+		for j, segment := range templateSegments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params[strings.Trim(segment, "{}")] = segments[j]
+			} else if segment != segments[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return &routes[i], params
+		}
+	}
+	return nil, nil
+}
+
+func (server *Server) serveHTTP(res http.ResponseWriter, req *http.Request) {
+	route, params := matchRoute(req.Method, req.URL.Path)
+	if route == nil {
+		writeJSON(res, http.StatusNotFound, errorBody(http.StatusNotFound, []vmwarev1.Error{
+			newError("not_found", fmt.Sprintf("no route for %s %s", req.Method, req.URL.Path)),
+		}))
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.requests[route.operation]++
+
+	if fault := server.takeFault(route.operation, req); fault != nil {
+		writeJSON(res, fault.StatusCode, errorBody(fault.StatusCode, fault.Errors))
+		return
+	}
+
+	server.advance(false)
+	status, body := route.handler(server, req, params)
+	writeJSON(res, status, body)
+}
+
+// takeFault returns the fault to apply to the request, if any.
+func (server *Server) takeFault(operation string, req *http.Request) *Fault {
+	if server.options.FaultHook != nil {
+		if fault := server.options.FaultHook(operation, req); fault != nil {
+			return fault
+		}
+	}
+	faults := server.faults[operation]
+	if len(faults) == 0 {
+		return nil
+	}
+	fault := faults[0]
+	if fault.Times > 0 {
+		fault.Times--
+		if fault.Times == 0 {
+			server.faults[operation] = faults[1:]
+		}
+	}
+	return fault
+}
+
+func (server *Server) newID(prefix string) string {
+	server.nextID++
+	return fmt.Sprintf("%s-%04d", prefix, server.nextID)
+}
+
+// failure builds the status and body of an error response.
+func failure(status int, code string, format string, args ...interface{}) (int, interface{}) {
+	return status, errorBody(status, []vmwarev1.Error{newError(code, fmt.Sprintf(format, args...))})
+}
+
+func errorBody(status int, errs []vmwarev1.Error) map[string]interface{} {
+	if len(errs) == 0 {
+		errs = []vmwarev1.Error{newError(strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"), http.StatusText(status))}
+	}
+	return map[string]interface{}{
+		"errors": errs,
+		"trace":  "fake-trace",
+	}
+}
+
+func newError(code string, message string) vmwarev1.Error {
+	return vmwarev1.Error{Code: core.StringPtr(code), Message: core.StringPtr(message)}
+}
+
+func writeJSON(res http.ResponseWriter, status int, body interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(res).Encode(body)
+	}
+}
+
+func decodeBody(req *http.Request, body interface{}) error {
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		return fmt.Errorf("invalid request body: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/**
+ * (C) Copyright IBM Corp. 2019, 2020.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastWait = &vmwarev1.WaitOptions{PollInterval: time.Millisecond, Timeout: 5 * time.Second}
+
+func createSite(t *testing.T, service *vmwarev1.VmwareV1) *vmwarev1.DirectorSite {
+	fileShares := &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(24000)}
+	cluster, err := service.NewClusterOrderInfo("cluster1", "dal10", 2, fileShares, HostProfile192GB)
+	require.Nil(t, err)
+	site, response, err := service.CreateWorkloadDomain(service.NewCreateWorkloadDomainOptions("site1", "Default", []vmwarev1.ClusterOrderInfo{*cluster}))
+	require.Nil(t, err)
+	assert.Equal(t, 202, response.StatusCode)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Creating, *site.Status)
+	return site
+}
+
+func TestProvisioningFlow(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	service, err := server.NewClient()
+	require.Nil(t, err)
+	ctx := context.Background()
+
+	site := createSite(t, service)
+	site, err = service.WaitForDirectorSite(ctx, *site.ID, fastWait)
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Readytouse, *site.Status)
+	assert.NotNil(t, site.InstanceCreated)
+	require.Len(t, site.Clusters, 1)
+	clusterID := *site.Clusters[0].ID
+
+	cluster, err := service.ResizeCluster(ctx, *site.ID, clusterID, 3, fastWait)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), *cluster.HostCount)
+
+	fileShares, response, err := service.SetFileShares(service.NewSetFileSharesOptions(*site.ID, clusterID).SetSTORAGEFOURIOPSGB(1000))
+	require.Nil(t, err)
+	assert.Equal(t, 202, response.StatusCode)
+	assert.Equal(t, int64(1000), *fileShares.STORAGEFOURIOPSGB)
+	cluster, err = service.WaitForCluster(ctx, *site.ID, clusterID, fastWait)
+	require.Nil(t, err)
+	assert.Equal(t, float64(1000), cluster.FileShares["STORAGE_FOUR_IOPS_GB"])
+
+	directorSite, err := service.NewNewVDCDirectorSite(*site.ID, &vmwarev1.VDCDirectorSiteCluster{ID: core.StringPtr(clusterID)})
+	require.Nil(t, err)
+	vdc, _, err := service.CreateVdc(service.NewCreateVdcOptions("vdc1", directorSite))
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.VDC_Status_Creating, *vdc.Status)
+	vdc, err = service.WaitForVdc(ctx, *vdc.ID, fastWait)
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.VDC_Status_Readytouse, *vdc.Status)
+	assert.NotEmpty(t, vdc.Edges[0].PublicIps)
+
+	vdcs, _, err := service.ListVdcs(service.NewListVdcsOptions())
+	require.Nil(t, err)
+	assert.Len(t, vdcs.Vdcs, 1)
+
+	password, _, err := service.ReplaceOrgAdminPassword(service.NewReplaceOrgAdminPasswordOptions(*site.ID))
+	require.Nil(t, err)
+	assert.NotEmpty(t, *password.Password)
+
+	_, _, err = service.DeleteVdc(service.NewDeleteVdcOptions(*vdc.ID))
+	require.Nil(t, err)
+	_, err = service.WaitForVdc(ctx, *vdc.ID, fastWait)
+	require.Nil(t, err)
+
+	_, _, err = service.DeleteWorkloadDomain(service.NewDeleteWorkloadDomainOptions(*site.ID))
+	require.Nil(t, err)
+	site, err = service.WaitForDirectorSite(ctx, *site.ID, &vmwarev1.WaitOptions{
+		PollInterval:   time.Millisecond,
+		TargetStatuses: []string{vmwarev1.DirectorSite_Status_Deleted},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Deleted, *site.Status)
+}
+
+func TestTransitionDelay(t *testing.T) {
+	now := time.Date(2022, 11, 15, 12, 0, 0, 0, time.UTC)
+	server := NewServer(&ServerOptions{
+		TransitionDelay: time.Hour,
+		Now:             func() time.Time { return now },
+	})
+	defer server.Close()
+	service, err := server.NewClient()
+	require.Nil(t, err)
+
+	site := createSite(t, service)
+	getOptions := service.NewGetSpecificWorkloadDomainInstanceOptions(*site.ID)
+	site, _, err = service.GetSpecificWorkloadDomainInstance(getOptions)
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Creating, *site.Status)
+	assert.Equal(t, "2022-11-15T12:00:00Z", *site.InstanceOrdered)
+
+	now = now.Add(time.Hour)
+	site, _, err = service.GetSpecificWorkloadDomainInstance(getOptions)
+	require.Nil(t, err)
+	assert.Equal(t, vmwarev1.DirectorSite_Status_Readytouse, *site.Status)
+
+	_, response, err := service.SetHostsCount(service.NewSetHostsCountOptions(*site.ID, *site.Clusters[0].ID, vmwarev1.MinimumClusterHostCount-1))
+	require.NotNil(t, err)
+	assert.Equal(t, 400, response.StatusCode)
+	_, _, err = service.SetHostsCount(service.NewSetHostsCountOptions(*site.ID, *site.Clusters[0].ID, 4))
+	require.Nil(t, err)
+	_, response, err = service.SetHostsCount(service.NewSetHostsCountOptions(*site.ID, *site.Clusters[0].ID, 5))
+	require.NotNil(t, err)
+	assert.Equal(t, 409, response.StatusCode)
+
+	server.CompletePending()
+	cluster, _, err := service.GetSpecificClusterInstance(service.NewGetSpecificClusterInstanceOptions(*site.ID, *site.Clusters[0].ID))
+	require.Nil(t, err)
+	assert.Equal(t, int64(4), *cluster.HostCount)
+}
+
+func TestInjectFault(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	service, err := server.NewClient()
+	require.Nil(t, err)
+
+	server.InjectFault("ListVdcs", Fault{
+		StatusCode: 503,
+		Errors:     []vmwarev1.Error{{Code: core.StringPtr("unavailable"), Message: core.StringPtr("try again later")}},
+		Times:      1,
+	})
+	_, response, err := service.ListVdcs(service.NewListVdcsOptions())
+	require.NotNil(t, err)
+	assert.Equal(t, 503, response.StatusCode)
+	assert.Contains(t, err.Error(), "try again later")
+
+	_, _, err = service.ListVdcs(service.NewListVdcsOptions())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, server.RequestCount("ListVdcs"))
+
+	server.InjectFault("GetRegions", Fault{StatusCode: 500})
+	_, _, err = service.GetRegions(service.NewGetRegionsOptions())
+	assert.NotNil(t, err)
+	_, _, err = service.GetRegions(service.NewGetRegionsOptions())
+	assert.NotNil(t, err)
+	server.ClearFaults()
+	regions, _, err := service.GetRegions(service.NewGetRegionsOptions())
+	require.Nil(t, err)
+	assert.Contains(t, regions.DirectorSiteRegions, "us-south")
+}
+
+func TestReferenceData(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	service, err := server.NewClient()
+	require.Nil(t, err)
+
+	profiles, _, err := service.ViewInstance(service.NewViewInstanceOptions())
+	require.Nil(t, err)
+	assert.Len(t, profiles.DirectorSiteHostProfiles, 3)
+
+	prices, _, err := service.ListPrices(service.NewListPricesOptions())
+	require.Nil(t, err)
+	assert.NotEmpty(t, prices.DirectorSitePricing)
+
+	quote, _, err := service.GetVcddPrice(service.NewGetVcddPriceOptions().SetCountry(DefaultCountry).SetClusters([]vmwarev1.DirectorSitePriceQuoteClusterInfo{
+		{
+			Name:        core.StringPtr("cluster1"),
+			HostProfile: core.StringPtr(HostProfile192GB),
+			HostCount:   core.Int64Ptr(2),
+			FileShares:  &vmwarev1.FileShares{STORAGETWOIOPSGB: core.Int64Ptr(1000)},
+		},
+	}))
+	require.Nil(t, err)
+	assert.Equal(t, DefaultCurrency, *quote.Currency)
+	assert.InDelta(t, 1500+2*3000+1000*0.12, *quote.Total, 0.001)
+
+	_, response, err := service.GetSpecificWorkloadDomainInstance(service.NewGetSpecificWorkloadDomainInstanceOptions("missing"))
+	require.NotNil(t, err)
+	assert.Equal(t, 404, response.StatusCode)
+}
@@ -0,0 +1,133 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vmwarev1otel : OpenTelemetry tracing of the operations of vmwarev1.VmwareV1
+//
+// A Tracer is a vmwarev1.Tracer that creates OpenTelemetry spans:
+//
+//	vmwareService.EnableTracing(vmwarev1otel.NewTracer(nil))
+package vmwarev1otel
+
+import (
+	"net/http"
+
+	common "github.com/hkantare/vmware-go-sdk/common"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer that creates the spans of VmwareV1.
+const TracerName = "github.com/hkantare/vmware-go-sdk/vmwarev1"
+
+// Attributes recorded on the spans of VmwareV1, in addition to the HTTP semantic conventions.
+const (
+	AttributeOperation  = attribute.Key("vmware.operation")
+	AttributeSiteID     = attribute.Key("vmware.site_id")
+	AttributeClusterID  = attribute.Key("vmware.cluster_id")
+	AttributeVdcID      = attribute.Key("vmware.vdc_id")
+	AttributeRetryCount = attribute.Key("vmware.retry_count")
+)
+
+// TracerOptions : Options for a Tracer
+type TracerOptions struct {
+	// The provider of the tracer. Defaults to the global provider returned by otel.GetTracerProvider.
+	TracerProvider trace.TracerProvider
+
+	// The propagator that injects the trace context into the request headers. Defaults to the W3C Trace Context
+	// propagator, propagation.TraceContext.
+	Propagator propagation.TextMapPropagator
+}
+
+// Tracer : OpenTelemetry tracing of VmwareV1 operations
+// Each operation gets a client span named after its operationId, such as "CreateWorkloadDomain", as a child of the
+// span in the context passed to the WithContext method. The span records the site_id, cluster_id and vdc_id of the
+// request, the HTTP method, URL and status code, and the number of retries, and the trace context is propagated in
+// the request headers.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+var _ vmwarev1.Tracer = (*Tracer)(nil)
+
+// NewTracer : Construct a Tracer
+func NewTracer(options *TracerOptions) *Tracer {
+	resolved := TracerOptions{}
+	if options != nil {
+		resolved = *options
+	}
+	if resolved.TracerProvider == nil {
+		resolved.TracerProvider = otel.GetTracerProvider()
+	}
+	if resolved.Propagator == nil {
+		resolved.Propagator = propagation.TraceContext{}
+	}
+	return &Tracer{
+		tracer:     resolved.TracerProvider.Tracer(TracerName, trace.WithInstrumentationVersion(common.Version)),
+		propagator: resolved.Propagator,
+	}
+}
+
+// StartSpan starts the client span of the operation and injects its trace context into the request headers.
+func (tracer *Tracer) StartSpan(operation vmwarev1.TracedOperation) (*http.Request, vmwarev1.Span) {
+	request := operation.Request
+	attributes := []attribute.KeyValue{
+		AttributeOperation.String(operation.Operation),
+		semconv.HTTPMethodKey.String(request.Method),
+		semconv.HTTPURLKey.String(request.URL.String()),
+	}
+	for _, resource := range []attribute.KeyValue{
+		AttributeSiteID.String(operation.SiteID),
+		AttributeClusterID.String(operation.ClusterID),
+		AttributeVdcID.String(operation.VdcID),
+	} {
+		if resource.Value.AsString() != "" {
+			attributes = append(attributes, resource)
+		}
+	}
+
+	ctx, span := tracer.tracer.Start(request.Context(), operation.Operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	request = request.WithContext(ctx)
+	tracer.propagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
+	return request, &operationSpan{span: span}
+}
+
+// operationSpan : The span of a single operation
+type operationSpan struct {
+	span trace.Span
+}
+
+// End records the outcome of the operation and ends the span.
+func (operationSpan *operationSpan) End(outcome vmwarev1.SpanOutcome) {
+	span := operationSpan.span
+	if outcome.Response != nil {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(outcome.Response.StatusCode))
+	}
+	span.SetAttributes(AttributeRetryCount.Int(outcome.Retries))
+	if outcome.Err != nil {
+		span.RecordError(outcome.Err)
+		span.SetStatus(codes.Error, outcome.Err.Error())
+	}
+	span.End()
+}
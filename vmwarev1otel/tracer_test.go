@@ -0,0 +1,171 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// testServer : A server that answers GetSpecificClusterInstance, failing with 503 while failures is positive
+type testServer struct {
+	*httptest.Server
+	mutex        sync.Mutex
+	traceparents []string
+	failures     int
+}
+
+func newTestServer() *testServer {
+	server := &testServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		server.traceparents = append(server.traceparents, req.Header.Get("traceparent"))
+		res.Header().Set("Content-type", "application/json")
+		if server.failures > 0 {
+			server.failures--
+			res.WriteHeader(503)
+			fmt.Fprint(res, `{"errors": [{"code": "unavailable", "message": "Try again later."}]}`)
+			return
+		}
+		res.WriteHeader(200)
+		fmt.Fprint(res, `{"id": "testCluster", "site_id": "testSite", "status": "ReadyToUse"}`)
+	}))
+	return server
+}
+
+func newTracedService(t *testing.T, server *testServer) (*vmwarev1.VmwareV1, *tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	service, err := vmwarev1.NewVmwareV1(&vmwarev1.VmwareV1Options{
+		URL:           server.URL + "/v1",
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	require.Nil(t, err)
+	service.EnableTracing(NewTracer(&TracerOptions{TracerProvider: provider}))
+	return service, exporter, provider
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	values := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		values[kv.Key] = kv.Value
+	}
+	return values
+}
+
+func TestTracerSpan(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	service, exporter, provider := newTracedService(t, server)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, _, err := service.GetSpecificClusterInstanceWithContext(ctx, service.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+	require.Nil(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "GetSpecificClusterInstance", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, TracerName, span.InstrumentationLibrary.Name)
+
+	keys := make([]attribute.Key, len(span.Attributes))
+	for i, kv := range span.Attributes {
+		keys[i] = kv.Key
+	}
+	assert.Equal(t, []attribute.Key{AttributeOperation, semconv.HTTPMethodKey, semconv.HTTPURLKey, AttributeSiteID, AttributeClusterID, semconv.HTTPStatusCodeKey, AttributeRetryCount}, keys)
+
+	values := attributes(span)
+	assert.Equal(t, "GetSpecificClusterInstance", values[AttributeOperation].AsString())
+	assert.Equal(t, "testSite", values[AttributeSiteID].AsString())
+	assert.Equal(t, "testCluster", values[AttributeClusterID].AsString())
+	assert.NotContains(t, values, AttributeVdcID)
+	assert.Equal(t, "GET", values[semconv.HTTPMethodKey].AsString())
+	assert.Equal(t, int64(200), values[semconv.HTTPStatusCodeKey].AsInt64())
+	assert.Equal(t, int64(0), values[AttributeRetryCount].AsInt64())
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	// The W3C trace context of the span is propagated to the service.
+	assert.Equal(t, []string{fmt.Sprintf("00-%s-%s-01", span.SpanContext.TraceID(), span.SpanContext.SpanID())}, server.traceparents)
+}
+
+func TestTracerResourceIDs(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	service, exporter, _ := newTracedService(t, server)
+
+	_, _, _ = service.ReplaceOrgAdminPassword(service.NewReplaceOrgAdminPasswordOptions("testSite"))
+	_, _, _ = service.GetVdc(service.NewGetVdcOptions("test/Vdc"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "testSite", attributes(spans[0])[AttributeSiteID].AsString())
+	assert.Equal(t, "test/Vdc", attributes(spans[1])[AttributeVdcID].AsString())
+}
+
+func TestTracerRetries(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	service, exporter, _ := newTracedService(t, server)
+	service.EnableRetries(2, 10*time.Millisecond)
+
+	server.failures = 1
+	_, _, err := service.GetSpecificClusterInstance(service.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), attributes(exporter.GetSpans()[0])[AttributeRetryCount].AsInt64())
+	exporter.Reset()
+
+	server.failures = 3
+	_, _, err = service.GetSpecificClusterInstance(service.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+	assert.True(t, vmwarev1.IsRetryable(err))
+	span := exporter.GetSpans()[0]
+	assert.Equal(t, int64(2), attributes(span)[AttributeRetryCount].AsInt64())
+	assert.Equal(t, int64(503), attributes(span)[semconv.HTTPStatusCodeKey].AsInt64())
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Len(t, span.Events, 1)
+}
+
+func TestTracerDisabled(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	service, exporter, _ := newTracedService(t, server)
+
+	service.DisableTracing()
+	_, _, err := service.GetSpecificClusterInstance(service.NewGetSpecificClusterInstanceOptions("testSite", "testCluster"))
+	require.Nil(t, err)
+	assert.Empty(t, exporter.GetSpans())
+	assert.Equal(t, []string{""}, server.traceparents)
+}
@@ -0,0 +1,140 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vmwarev1prom : Prometheus metrics for the requests sent by vmwarev1.VmwareV1
+//
+// A Collector is both a vmwarev1.MetricsCollector and a prometheus.Collector:
+//
+//	collector := vmwarev1prom.NewCollector(nil)
+//	prometheus.MustRegister(collector)
+//	vmwareService.EnableMetrics(collector)
+package vmwarev1prom
+
+import (
+	"strconv"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the namespace of the metrics when CollectorOptions does not set one.
+const DefaultNamespace = "vmware"
+
+// The labels of every metric.
+const (
+	LabelOperation = "operation"
+	LabelMethod    = "method"
+	LabelCode      = "code"
+)
+
+// CollectorOptions : Options for a Collector
+type CollectorOptions struct {
+	// The namespace of the metric names. Defaults to DefaultNamespace.
+	Namespace string
+
+	// The subsystem of the metric names, empty by default.
+	Subsystem string
+
+	// Labels added to every metric, such as the region of the service.
+	ConstLabels prometheus.Labels
+
+	// The buckets of the latency histogram, in seconds. Defaults to prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Collector : Request metrics of VmwareV1 exported to Prometheus
+// Every metric is labelled by operation (the operationId, such as "GetVcddPrice"), HTTP method and HTTP status code.
+// The code is "0" when no response was received, for example after a connection error.
+//
+//   - <namespace>_requests_total counts the requests.
+//   - <namespace>_request_errors_total counts the requests that returned an error.
+//   - <namespace>_request_duration_seconds is a histogram of the request latency, retries included.
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+var _ vmwarev1.MetricsCollector = (*Collector)(nil)
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector : Construct a Collector
+func NewCollector(options *CollectorOptions) *Collector {
+	resolved := CollectorOptions{}
+	if options != nil {
+		resolved = *options
+	}
+	if resolved.Namespace == "" {
+		resolved.Namespace = DefaultNamespace
+	}
+	if resolved.Buckets == nil {
+		resolved.Buckets = prometheus.DefBuckets
+	}
+
+	labels := []string{LabelOperation, LabelMethod, LabelCode}
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   resolved.Namespace,
+			Subsystem:   resolved.Subsystem,
+			Name:        "requests_total",
+			Help:        "Number of VMware as a Service API requests.",
+			ConstLabels: resolved.ConstLabels,
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   resolved.Namespace,
+			Subsystem:   resolved.Subsystem,
+			Name:        "request_errors_total",
+			Help:        "Number of VMware as a Service API requests that returned an error.",
+			ConstLabels: resolved.ConstLabels,
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   resolved.Namespace,
+			Subsystem:   resolved.Subsystem,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of VMware as a Service API requests, retries included.",
+			ConstLabels: resolved.ConstLabels,
+			Buckets:     resolved.Buckets,
+		}, labels),
+	}
+}
+
+// ObserveRequest records the observation, implementing vmwarev1.MetricsCollector.
+func (collector *Collector) ObserveRequest(observation vmwarev1.RequestObservation) {
+	labels := prometheus.Labels{
+		LabelOperation: observation.Operation,
+		LabelMethod:    observation.Method,
+		LabelCode:      strconv.Itoa(observation.StatusCode),
+	}
+	collector.requests.With(labels).Inc()
+	if observation.Err != nil {
+		collector.errors.With(labels).Inc()
+	}
+	collector.duration.With(labels).Observe(observation.Duration.Seconds())
+}
+
+// Describe sends the descriptors of the metrics, implementing prometheus.Collector.
+func (collector *Collector) Describe(ch chan<- *prometheus.Desc) {
+	collector.requests.Describe(ch)
+	collector.errors.Describe(ch)
+	collector.duration.Describe(ch)
+}
+
+// Collect sends the current values of the metrics, implementing prometheus.Collector.
+func (collector *Collector) Collect(ch chan<- prometheus.Metric) {
+	collector.requests.Collect(ch)
+	collector.errors.Collect(ch)
+	collector.duration.Collect(ch)
+}
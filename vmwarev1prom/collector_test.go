@@ -0,0 +1,76 @@
+/**
+ * (C) Copyright IBM Corp. 2022.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vmwarev1prom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hkantare/vmware-go-sdk/vmwarev1"
+	"github.com/hkantare/vmware-go-sdk/vmwarev1fake"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	server := vmwarev1fake.NewServer(nil)
+	defer server.Close()
+	service, err := server.NewClient()
+	require.Nil(t, err)
+
+	collector := NewCollector(&CollectorOptions{ConstLabels: prometheus.Labels{"region": "us-south"}})
+	registry := prometheus.NewPedanticRegistry()
+	require.Nil(t, registry.Register(collector))
+	service.EnableMetrics(collector)
+
+	_, _, err = service.GetRegions(service.NewGetRegionsOptions())
+	require.Nil(t, err)
+	_, _, err = service.GetRegions(service.NewGetRegionsOptions())
+	require.Nil(t, err)
+	server.InjectFault("SetHostsCount", vmwarev1fake.Fault{StatusCode: 503, Times: 1})
+	_, _, err = service.SetHostsCount(service.NewSetHostsCountOptions("site", "cluster", 3))
+	require.NotNil(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(collector.requests.WithLabelValues("GetRegions", "GET", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.requests.WithLabelValues("SetHostsCount", "PUT", "503")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.errors.WithLabelValues("SetHostsCount", "PUT", "503")))
+
+	expected := `
+# HELP vmware_request_errors_total Number of VMware as a Service API requests that returned an error.
+# TYPE vmware_request_errors_total counter
+vmware_request_errors_total{code="503",method="PUT",operation="SetHostsCount",region="us-south"} 1
+`
+	assert.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "vmware_request_errors_total"))
+
+	count, err := testutil.GatherAndCount(registry, "vmware_request_duration_seconds")
+	require.Nil(t, err)
+	assert.Equal(t, 2, count)
+	problems, err := testutil.GatherAndLint(registry)
+	require.Nil(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCollectorNoResponse(t *testing.T) {
+	collector := NewCollector(&CollectorOptions{Namespace: "test", Buckets: []float64{1}})
+	collector.ObserveRequest(vmwarev1.RequestObservation{Operation: "ListVdcs", Method: "GET", Err: assert.AnError})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.errors.WithLabelValues("ListVdcs", "GET", "0")))
+	assert.Equal(t, 3, testutil.CollectAndCount(collector))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "test_requests_total"))
+}